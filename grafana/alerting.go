@@ -0,0 +1,148 @@
+package grafana
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	gapi "github.com/nytm/go-grafana-api"
+)
+
+// alertingDurationPattern matches the durations of unified alerting, which
+// are Prometheus durations such as 1h30m or 1w, with units down to ms.
+var alertingDurationPattern = regexp.MustCompile(`^(?:(\d+)y)?(?:(\d+)w)?(?:(\d+)d)?(?:(\d+)h)?(?:(\d+)m)?(?:(\d+)s)?(?:(\d+)ms)?$`)
+
+var alertingDurationUnits = []time.Duration{
+	365 * 24 * time.Hour,
+	7 * 24 * time.Hour,
+	24 * time.Hour,
+	time.Hour,
+	time.Minute,
+	time.Second,
+	time.Millisecond,
+}
+
+func parseAlertingDuration(s string) (time.Duration, error) {
+	match := alertingDurationPattern.FindStringSubmatch(s)
+	if s == "" || match == nil {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	var duration time.Duration
+	for i, unit := range alertingDurationUnits {
+		if match[i+1] == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(match[i+1], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %s", s, err)
+		}
+		duration += time.Duration(n) * unit
+	}
+	return duration, nil
+}
+
+func validateAlertingDuration(v interface{}, k string) ([]string, []error) {
+	if _, err := parseAlertingDuration(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%q must be a duration, e.g. 5m or 1h30m, got %q", k, v)}
+	}
+	return nil, nil
+}
+
+// suppressAlertingDurationDiff compares durations by their length, since
+// Grafana returns them in its own format, e.g. 1h for 60m.
+func suppressAlertingDurationDiff(k, old, new string, d *schema.ResourceData) bool {
+	o, err := parseAlertingDuration(old)
+	if err != nil {
+		return false
+	}
+	n, err := parseAlertingDuration(new)
+	if err != nil {
+		return false
+	}
+	return o == n
+}
+
+// alertingDurationSchema is the schema of an optional duration of unified
+// alerting.
+func alertingDurationSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateFunc:     validateAlertingDuration,
+		DiffSuppressFunc: suppressAlertingDurationDiff,
+	}
+}
+
+// disableProvenanceSchema is the schema of the disable_provenance attribute
+// of alerting resources. Grafana marks what is provisioned through its API
+// as such, and doesn't let it be edited in its web UI, unless told not to.
+// Grafana refuses to remove the provenance of an object once it's set, so
+// changing it recreates the object.
+func disableProvenanceSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		ForceNew:    true,
+		Default:     false,
+		Description: "Allow the resource to be edited in Grafana's web UI.",
+	}
+}
+
+// alertingClient returns the client for the organization an alerting
+// resource is managed in, which also sends the X-Disable-Provenance header
+// when the resource has disable_provenance set.
+func alertingClient(d *schema.ResourceData, meta interface{}) (*gapi.Client, error) {
+	apiClient, err := orgClient(d, meta)
+	if err != nil || !d.Get("disable_provenance").(bool) {
+		return apiClient, err
+	}
+
+	withoutProvenance := *apiClient
+	withoutProvenance.Client = &http.Client{
+		Transport: &headerTransport{
+			headers:   map[string]string{"X-Disable-Provenance": "true"},
+			transport: apiClient.Transport,
+		},
+		Timeout: apiClient.Timeout,
+	}
+	return &withoutProvenance, nil
+}
+
+func stringList(values []interface{}) []string {
+	var strings []string
+	for _, value := range values {
+		strings = append(strings, value.(string))
+	}
+	return strings
+}
+
+func stringMap(values map[string]interface{}) map[string]string {
+	strings := map[string]string{}
+	for key, value := range values {
+		strings[key] = value.(string)
+	}
+	return strings
+}
+
+// computedSchema returns a copy of the schema of a resource attribute, along
+// with the blocks nested in it, with every field computed, so that data
+// sources can export what resources manage.
+func computedSchema(s *schema.Schema) *schema.Schema {
+	computed := &schema.Schema{
+		Type:      s.Type,
+		Computed:  true,
+		Sensitive: s.Sensitive,
+		Elem:      s.Elem,
+	}
+	if elem, ok := s.Elem.(*schema.Resource); ok {
+		nested := map[string]*schema.Schema{}
+		for name, field := range elem.Schema {
+			nested[name] = computedSchema(field)
+		}
+		computed.Elem = &schema.Resource{Schema: nested}
+	}
+	return computed
+}
@@ -0,0 +1,62 @@
+package grafana
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// azureManagedGrafanaAppID is the application ID of Azure Managed Grafana,
+// which access tokens must be issued for.
+const azureManagedGrafanaAppID = "ce34e7e5-485f-4d76-964f-b3d2b16d1e4f"
+
+// azureIMDSTokenURL is the instance metadata service endpoint that issues
+// tokens for the managed identity of the Azure VM the provider runs on.
+var azureIMDSTokenURL = "http://169.254.169.254/metadata/identity/oauth2/token"
+
+// azureADClientCredentials returns a function fetching tokens for Azure
+// Managed Grafana from Azure AD using a service principal's client secret.
+func azureADClientCredentials(httpClient *http.Client, authorityHost, tenantID, clientID, clientSecret string) func() (*token, error) {
+	tokenURL := strings.TrimSuffix(authorityHost, "/") + "/" + url.PathEscape(tenantID) + "/oauth2/v2.0/token"
+
+	return func() (*token, error) {
+		form := url.Values{
+			"grant_type":    {"client_credentials"},
+			"client_id":     {clientID},
+			"client_secret": {clientSecret},
+			"scope":         {azureManagedGrafanaAppID + "/.default"},
+		}
+
+		req, err := http.NewRequest("POST", tokenURL, strings.NewReader(form.Encode()))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		return doTokenRequest(httpClient, req)
+	}
+}
+
+// azureManagedIdentity returns a function fetching tokens for Azure Managed
+// Grafana for the managed identity of the machine the provider runs on. A
+// clientID selects a user-assigned identity; when empty the system-assigned
+// identity is used.
+func azureManagedIdentity(httpClient *http.Client, clientID string) func() (*token, error) {
+	return func() (*token, error) {
+		query := url.Values{
+			"api-version": {"2018-02-01"},
+			"resource":    {azureManagedGrafanaAppID},
+		}
+		if clientID != "" {
+			query.Set("client_id", clientID)
+		}
+
+		req, err := http.NewRequest("GET", azureIMDSTokenURL+"?"+query.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Metadata", "true")
+
+		return doTokenRequest(httpClient, req)
+	}
+}
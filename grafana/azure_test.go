@@ -0,0 +1,57 @@
+package grafana
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAzureADClientCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/my-tenant/oauth2/v2.0/token" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		r.ParseForm()
+		if r.PostForm.Get("grant_type") != "client_credentials" ||
+			r.PostForm.Get("client_id") != "my-client" ||
+			r.PostForm.Get("client_secret") != "my-secret" ||
+			r.PostForm.Get("scope") != azureManagedGrafanaAppID+"/.default" {
+			t.Errorf("unexpected token request %v", r.PostForm)
+		}
+		w.Write([]byte(`{"access_token": "aad-token", "expires_in": 3599}`))
+	}))
+	defer server.Close()
+
+	tok, err := azureADClientCredentials(http.DefaultClient, server.URL+"/", "my-tenant", "my-client", "my-secret")()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if tok.accessToken != "aad-token" {
+		t.Fatalf("expected aad-token, got %q", tok.accessToken)
+	}
+}
+
+func TestAzureManagedIdentity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata") != "true" {
+			t.Errorf("expected the Metadata header to be set")
+		}
+		if r.URL.Query().Get("resource") != azureManagedGrafanaAppID || r.URL.Query().Get("client_id") != "my-identity" {
+			t.Errorf("unexpected token request %s", r.URL)
+		}
+		w.Write([]byte(`{"access_token": "msi-token", "expires_in": "3599"}`))
+	}))
+	defer server.Close()
+
+	oldURL := azureIMDSTokenURL
+	azureIMDSTokenURL = server.URL
+	defer func() { azureIMDSTokenURL = oldURL }()
+
+	tok, err := azureManagedIdentity(http.DefaultClient, "my-identity")()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if tok.accessToken != "msi-token" {
+		t.Fatalf("expected msi-token, got %q", tok.accessToken)
+	}
+}
@@ -0,0 +1,371 @@
+package grafana
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform/helper/schema"
+
+	gapi "github.com/nytm/go-grafana-api"
+)
+
+// client is the meta value shared by all resources. gapi is scoped to the
+// organization configured on the provider; resources that set their own
+// org_id get a dedicated client from forOrg instead, so that no request ever
+// depends on mutable "current organization" state on the server.
+type client struct {
+	gapi *gapi.Client
+
+	// cloud is the client for the Grafana Cloud API. It is nil unless the
+	// provider was configured with a cloud_api_key.
+	cloud *gapi.Client
+
+	// oncall is the client for the Grafana OnCall API. It is nil unless the
+	// provider was configured with an oncall_access_token.
+	oncall *gapi.Client
+
+	// sm is the client for the Synthetic Monitoring API. It is nil unless
+	// the provider was configured with an sm_access_token.
+	sm *gapi.Client
+
+	// version is the version of the Grafana server, as reported by its
+	// health endpoint when the provider was configured.
+	version *version.Version
+
+	// edition is the edition of the Grafana server, e.g. "Open Source" or
+	// "Enterprise". It is only looked up once a resource needs it.
+	editionMu sync.Mutex
+	edition   string
+
+	// orgID is the organization configured on the provider, or zero if it
+	// is the one of the provider's credentials.
+	orgID int64
+
+	auth      string
+	url       string
+	timeout   time.Duration
+	transport http.RoundTripper
+
+	orgClientsMu sync.Mutex
+	orgClients   map[int64]*gapi.Client
+
+	usersMu       sync.Mutex
+	userIDsByName map[string]int64
+
+	// defaultDataSources are the names of the data sources made the default
+	// of each organization in this run, by org_id.
+	defaultDataSourcesMu sync.Mutex
+	defaultDataSources   map[int64]string
+}
+
+// newAPIClient builds a Grafana API client. When orgID is greater than zero
+// every request made by the client is scoped to that organization.
+func (c *client) newAPIClient(orgID int64) (*gapi.Client, error) {
+	apiClient, err := gapi.New(c.auth, c.url)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := c.transport
+	if orgID > 0 {
+		transport = &orgIDTransport{orgID, transport}
+	}
+
+	apiClient.Transport = transport
+	apiClient.Timeout = c.timeout
+
+	return apiClient, nil
+}
+
+// checkHealth verifies that the Grafana server is reachable and records its
+// version, so that a misconfigured URL is reported clearly when the provider
+// is configured instead of as a confusing error from the first resource.
+func (c *client) checkHealth() error {
+	health, err := c.gapi.Health()
+	if err != nil {
+		return fmt.Errorf("Error connecting to Grafana at %s: %s. Check that the url argument points to the root of a Grafana server", c.url, err)
+	}
+
+	v, err := version.NewVersion(health.Version)
+	if err != nil {
+		return fmt.Errorf("Error connecting to Grafana at %s: unexpected version %q reported by the health endpoint", c.url, health.Version)
+	}
+	c.version = v
+
+	log.Printf("[DEBUG] Connected to Grafana %s at %s (database: %s)", health.Version, c.url, health.Database)
+
+	return nil
+}
+
+// requireVersion fails with a clear error when the Grafana server is older
+// than minVersion, the first version providing the API behind feature.
+// Resources call it before using such APIs, instead of letting users puzzle
+// over the 404s an older server would respond with.
+func (c *client) requireVersion(feature, minVersion string) error {
+	if c.version == nil {
+		return nil
+	}
+
+	min := version.Must(version.NewVersion(minVersion))
+	// Pre-releases of a version already provide its APIs.
+	current := version.Must(version.NewVersion(strings.SplitN(c.version.String(), "-", 2)[0]))
+	if current.LessThan(min) {
+		return fmt.Errorf("%s requires Grafana >= %s, but %s is running %s", feature, minVersion, c.url, c.version)
+	}
+
+	return nil
+}
+
+// requireEnterprise fails with a clear error when the Grafana server isn't
+// running Grafana Enterprise, which feature is exclusive to.
+func (c *client) requireEnterprise(feature string) error {
+	c.editionMu.Lock()
+	defer c.editionMu.Unlock()
+
+	if c.edition == "" {
+		apiClient, err := c.forOrg(0)
+		if err != nil {
+			return err
+		}
+
+		settings, err := apiClient.FrontendSettings()
+		if err != nil {
+			return fmt.Errorf("Error detecting the Grafana edition: %s", err)
+		}
+		c.edition = settings.BuildInfo.Edition
+	}
+
+	if !strings.Contains(strings.ToLower(c.edition), "enterprise") && !strings.Contains(strings.ToLower(c.edition), "cloud") {
+		return fmt.Errorf("%s requires Grafana Enterprise, but %s is running the %q edition", feature, c.url, c.edition)
+	}
+
+	return nil
+}
+
+// cloudClient returns the Grafana Cloud API client, failing with a clear
+// error if the provider wasn't configured for Grafana Cloud.
+func (c *client) cloudClient() (*gapi.Client, error) {
+	if c.cloud == nil {
+		return nil, fmt.Errorf("The provider's cloud_api_key argument must be set to manage Grafana Cloud resources")
+	}
+	return c.cloud, nil
+}
+
+// onCallClient returns the Grafana OnCall API client, failing with a clear
+// error if the provider wasn't configured for OnCall.
+func (c *client) onCallClient() (*gapi.Client, error) {
+	if c.oncall == nil {
+		return nil, fmt.Errorf("The provider's oncall_access_token argument must be set to manage Grafana OnCall resources")
+	}
+	return c.oncall, nil
+}
+
+// smClient returns the Synthetic Monitoring API client, failing with a
+// clear error if the provider wasn't configured for Synthetic Monitoring.
+func (c *client) smClient() (*gapi.Client, error) {
+	if c.sm == nil {
+		return nil, fmt.Errorf("The provider's sm_access_token argument must be set to manage Synthetic Monitoring resources")
+	}
+	return c.sm, nil
+}
+
+// forOrg returns the client for the given organization, creating it on first
+// use. An orgID of zero returns the provider's default client.
+func (c *client) forOrg(orgID int64) (*gapi.Client, error) {
+	if c.gapi == nil {
+		return nil, fmt.Errorf("The provider's url and auth arguments must be set to manage Grafana resources")
+	}
+
+	if orgID == 0 {
+		return c.gapi, nil
+	}
+
+	c.orgClientsMu.Lock()
+	defer c.orgClientsMu.Unlock()
+
+	if apiClient, ok := c.orgClients[orgID]; ok {
+		return apiClient, nil
+	}
+
+	apiClient, err := c.newAPIClient(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.orgClients == nil {
+		c.orgClients = map[int64]*gapi.Client{}
+	}
+	c.orgClients[orgID] = apiClient
+
+	return apiClient, nil
+}
+
+// usersPerPage is the number of users listed per request. Grafana lists
+// users a page at a time, and only returns the first page unless told
+// otherwise.
+const usersPerPage = 1000
+
+// userIDs returns the IDs of all users of the Grafana instance, keyed by
+// both lowercased email and lowercased login, so users can be referred to
+// by either regardless of case. Should a login be the email of another
+// user, the email wins. The listing is
+// fetched at most once per Terraform run and shared by every resource that
+// needs to resolve users, so large instances aren't listed once per
+// resource. The returned map must not be modified.
+func (c *client) userIDs() (map[string]int64, error) {
+	c.usersMu.Lock()
+	defer c.usersMu.Unlock()
+
+	if c.userIDsByName != nil {
+		return c.userIDsByName, nil
+	}
+
+	apiClient, err := c.forOrg(0)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []gapi.User
+	for page := 1; ; page++ {
+		pageUsers, err := apiClient.UsersPage(page, usersPerPage)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, pageUsers...)
+		if len(pageUsers) < usersPerPage {
+			break
+		}
+	}
+
+	c.userIDsByName = make(map[string]int64, 2*len(users))
+	for _, user := range users {
+		if user.Login != "" {
+			c.userIDsByName[strings.ToLower(user.Login)] = user.Id
+		}
+	}
+	for _, user := range users {
+		if user.Email != "" {
+			c.userIDsByName[strings.ToLower(user.Email)] = user.Id
+		}
+	}
+
+	return c.userIDsByName, nil
+}
+
+// invalidateUsers drops the cached user listing. Resources must call it
+// after creating or deleting users so later lookups see the change.
+func (c *client) invalidateUsers() {
+	c.usersMu.Lock()
+	defer c.usersMu.Unlock()
+
+	c.userIDsByName = nil
+}
+
+// claimDefaultDataSource records that a data source is made the default of
+// an organization, and fails if another data source already was in this
+// run. Grafana lets only one data source be the default, so two data
+// sources configured as the default would take it from each other on every
+// run, both always showing up as changed. An orgID of zero is the provider's
+// organization.
+func (c *client) claimDefaultDataSource(orgID int64, name string) error {
+	c.defaultDataSourcesMu.Lock()
+	defer c.defaultDataSourcesMu.Unlock()
+
+	if orgID == 0 {
+		orgID = c.orgID
+	}
+	if c.defaultDataSources == nil {
+		c.defaultDataSources = map[int64]string{}
+	}
+	if other, ok := c.defaultDataSources[orgID]; ok && other != name {
+		return fmt.Errorf("Data sources %q and %q both have is_default set, but only one data source of an organization can be the default", other, name)
+	}
+	c.defaultDataSources[orgID] = name
+	return nil
+}
+
+// releaseDefaultDataSource drops the claim of a data source on the default
+// of an organization, once it no longer is or couldn't be made the default.
+func (c *client) releaseDefaultDataSource(orgID int64, name string) {
+	c.defaultDataSourcesMu.Lock()
+	defer c.defaultDataSourcesMu.Unlock()
+
+	if orgID == 0 {
+		orgID = c.orgID
+	}
+	if c.defaultDataSources[orgID] == name {
+		delete(c.defaultDataSources, orgID)
+	}
+}
+
+// orgIDSchema is the schema for the org_id attribute of resources that can
+// be managed in an organization other than the provider's.
+func orgIDSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeInt,
+		Optional:    true,
+		ForceNew:    true,
+		Description: "The organization the resource is managed in. Defaults to the provider's organization.",
+	}
+}
+
+// orgClient returns the client for the organization a resource is managed
+// in, as given by its org_id attribute.
+func orgClient(d *schema.ResourceData, meta interface{}) (*gapi.Client, error) {
+	return meta.(*client).forOrg(int64(d.Get("org_id").(int)))
+}
+
+// makeOrgResourceID returns the ID of a resource managed in the given
+// organization, which is prefixed with the organization's ID unless the
+// resource is managed in the provider's organization, e.g. 2:name.
+func makeOrgResourceID(orgID int64, id string) string {
+	if orgID == 0 {
+		return id
+	}
+	return fmt.Sprintf("%d:%s", orgID, id)
+}
+
+// orgResourceID returns the ID of a resource within its organization, as
+// given by its org_id attribute. Resources created before their IDs were
+// prefixed keep working.
+func orgResourceID(d *schema.ResourceData) string {
+	orgID := d.Get("org_id").(int)
+	if orgID == 0 {
+		return d.Id()
+	}
+	return strings.TrimPrefix(d.Id(), fmt.Sprintf("%d:", orgID))
+}
+
+// setOrgResourceID sets the ID of a resource from its ID within its
+// organization.
+func setOrgResourceID(d *schema.ResourceData, id string) {
+	d.SetId(makeOrgResourceID(int64(d.Get("org_id").(int)), id))
+}
+
+// splitOrgResourceID splits the organization's ID off the ID of a resource
+// being imported. IDs without a numeric prefix are of the provider's
+// organization.
+func splitOrgResourceID(id string) (int64, string) {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) == 2 && parts[1] != "" {
+		if orgID, err := strconv.ParseInt(parts[0], 10, 64); err == nil && orgID > 0 {
+			return orgID, parts[1]
+		}
+	}
+	return 0, id
+}
+
+// importOrgResource imports a resource by its ID, optionally prefixed with
+// the ID of the organization it is managed in, e.g. 2:name.
+func importOrgResource(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	orgID, _ := splitOrgResourceID(d.Id())
+	d.Set("org_id", int(orgID))
+
+	return []*schema.ResourceData{d}, nil
+}
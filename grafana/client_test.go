@@ -0,0 +1,293 @@
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	gapi "github.com/nytm/go-grafana-api"
+)
+
+func TestClientForOrg(t *testing.T) {
+	var gotOrgID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotOrgID = r.Header.Get("X-Grafana-Org-Id")
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	defaultClient, err := c.forOrg(0)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if defaultClient != c.gapi {
+		t.Fatalf("expected org 0 to use the provider client")
+	}
+	if _, err := defaultClient.DataSource(1); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if gotOrgID != "" {
+		t.Fatalf("expected no org header, got %q", gotOrgID)
+	}
+
+	orgClient, err := c.forOrg(2)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if _, err := orgClient.DataSource(1); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if gotOrgID != "2" {
+		t.Fatalf("expected org header 2, got %q", gotOrgID)
+	}
+
+	again, err := c.forOrg(2)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if again != orgClient {
+		t.Fatalf("expected the org client to be reused")
+	}
+}
+
+func TestClientCheckHealth(t *testing.T) {
+	cases := []struct {
+		status  int
+		body    string
+		version string
+	}{
+		{http.StatusOK, `{"commit": "abc", "database": "ok", "version": "5.1.3"}`, "5.1.3"},
+		{http.StatusOK, `{"commit": "abc", "database": "ok", "version": "6.0.0-beta1"}`, "6.0.0-beta1"},
+		{http.StatusNotFound, `Not found`, ""},
+		{http.StatusOK, `<html></html>`, ""},
+		{http.StatusOK, `{"version": ""}`, ""},
+	}
+
+	for _, tc := range cases {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/api/health" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(tc.status)
+			w.Write([]byte(tc.body))
+		}))
+
+		c := newTestClient(t, server)
+
+		err := c.checkHealth()
+		server.Close()
+
+		if tc.version == "" {
+			if err == nil {
+				t.Errorf("expected %d %q to fail the health check", tc.status, tc.body)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("expected %q to pass the health check, got %s", tc.body, err)
+			continue
+		}
+		if c.version.String() != tc.version {
+			t.Errorf("expected version %s, got %s", tc.version, c.version)
+		}
+	}
+}
+
+func TestClientUserIDs(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`[{"Id": 1, "Email": "admin@localhost", "Login": "admin"}, {"Id": 2, "Email": "User@Example.com", "Login": "user"}, {"Id": 3, "Login": "sso-user"}]`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	for i := 0; i < 3; i++ {
+		users, err := c.userIDs()
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if users["user@example.com"] != 2 || users["user"] != 2 {
+			t.Fatalf("expected user@example.com and user to have ID 2, got %v", users)
+		}
+		if users["sso-user"] != 3 {
+			t.Fatalf("expected sso-user to have ID 3, got %v", users)
+		}
+		if _, ok := users[""]; ok {
+			t.Fatalf("expected users without an email not to be listed by an empty email, got %v", users)
+		}
+	}
+	if requests != 1 {
+		t.Fatalf("expected users to be listed once, got %d requests", requests)
+	}
+
+	c.invalidateUsers()
+	if _, err := c.userIDs(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if requests != 2 {
+		t.Fatalf("expected users to be listed again after invalidation, got %d requests", requests)
+	}
+}
+
+func TestClientUserIDs_paginated(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("perpage") != strconv.Itoa(usersPerPage) {
+			t.Errorf("unexpected page size %q", r.URL.Query().Get("perpage"))
+		}
+
+		var users []gapi.User
+		switch r.URL.Query().Get("page") {
+		case "1":
+			for i := 1; i <= usersPerPage; i++ {
+				users = append(users, gapi.User{Id: int64(i), Login: fmt.Sprintf("user-%d", i)})
+			}
+		case "2":
+			users = append(users, gapi.User{Id: usersPerPage + 1, Login: "last-user"})
+		}
+		json.NewEncoder(w).Encode(users)
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	users, err := c.userIDs()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(users) != usersPerPage+1 || users["last-user"] != usersPerPage+1 {
+		t.Fatalf("expected users from every page, got %d users", len(users))
+	}
+}
+
+func TestClientRequireVersion(t *testing.T) {
+	cases := []struct {
+		version    string
+		minVersion string
+		ok         bool
+	}{
+		{"5.1.3", "5.0.0", true},
+		{"5.0.0", "5.0.0", true},
+		{"5.0.0-beta1", "5.0.0", true},
+		{"4.6.3", "5.0.0", false},
+		{"10.4.0", "9.1.0", true},
+	}
+
+	for _, tc := range cases {
+		c := &client{url: "http://grafana.local", version: version.Must(version.NewVersion(tc.version))}
+		err := c.requireVersion("grafana_folder", tc.minVersion)
+		if tc.ok && err != nil {
+			t.Errorf("expected %s to satisfy %s, got %s", tc.version, tc.minVersion, err)
+		}
+		if !tc.ok && err == nil {
+			t.Errorf("expected %s not to satisfy %s", tc.version, tc.minVersion)
+		}
+	}
+
+	if err := (&client{}).requireVersion("grafana_folder", "5.0.0"); err != nil {
+		t.Errorf("expected an unknown version to be allowed, got %s", err)
+	}
+}
+
+func TestClientRequireEnterprise(t *testing.T) {
+	for edition, ok := range map[string]bool{"Open Source": false, "Enterprise": true} {
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			fmt.Fprintf(w, `{"buildInfo": {"version": "9.1.0", "edition": %q}}`, edition)
+		}))
+
+		c := newTestClient(t, server)
+
+		for i := 0; i < 2; i++ {
+			err := c.requireEnterprise("grafana_data_source_permission")
+			if ok && err != nil {
+				t.Errorf("expected %q to be accepted, got %s", edition, err)
+			}
+			if !ok && err == nil {
+				t.Errorf("expected %q to be rejected", edition)
+			}
+		}
+		server.Close()
+
+		if requests != 1 {
+			t.Errorf("expected the edition to be looked up once, got %d requests", requests)
+		}
+	}
+}
+
+func TestClientClaimDefaultDataSource(t *testing.T) {
+	c := &client{}
+	if err := c.claimDefaultDataSource(0, "prometheus"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := c.claimDefaultDataSource(0, "prometheus"); err != nil {
+		t.Fatalf("expected a data source to claim the default again, got %s", err)
+	}
+	if err := c.claimDefaultDataSource(2, "loki"); err != nil {
+		t.Fatalf("expected the default of another organization to be claimed, got %s", err)
+	}
+	if err := c.claimDefaultDataSource(0, "influxdb"); err == nil {
+		t.Fatalf("expected a second default data source to be rejected")
+	}
+	c.releaseDefaultDataSource(0, "prometheus")
+	if err := c.claimDefaultDataSource(0, "influxdb"); err != nil {
+		t.Fatalf("expected the released default to be claimed, got %s", err)
+	}
+
+	// The provider's organization is the same whether or not it's given.
+	c = &client{orgID: 3}
+	if err := c.claimDefaultDataSource(0, "prometheus"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := c.claimDefaultDataSource(3, "loki"); err == nil {
+		t.Fatalf("expected a second default data source of the provider's organization to be rejected")
+	}
+}
+
+func TestOrgResourceID(t *testing.T) {
+	d := ResourceMuteTiming().Data(nil)
+	setOrgResourceID(d, "maintenance")
+	if d.Id() != "maintenance" || orgResourceID(d) != "maintenance" {
+		t.Fatalf("expected the id of the provider's organization to be unprefixed, got %q", d.Id())
+	}
+
+	d.Set("org_id", 2)
+	if orgResourceID(d) != "maintenance" {
+		t.Fatalf("expected ids from before they were prefixed to be kept, got %q", orgResourceID(d))
+	}
+	setOrgResourceID(d, "maintenance")
+	if d.Id() != "2:maintenance" || orgResourceID(d) != "maintenance" {
+		t.Fatalf("expected the id to be prefixed with the organization, got %q", d.Id())
+	}
+}
+
+func TestImportOrgResource(t *testing.T) {
+	for id, orgID := range map[string]int{
+		"maintenance":      0,
+		"2:maintenance":    2,
+		"2:main:tenance":   2,
+		"main:tenance":     0,
+		"2:":               0,
+		"0:maintenance":    0,
+		"-1:maintenance":   0,
+		"15:maintenance:2": 15,
+	} {
+		d := ResourceMuteTiming().Data(nil)
+		d.SetId(id)
+		if _, err := importOrgResource(d, nil); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if got := d.Get("org_id").(int); got != orgID {
+			t.Fatalf("expected %s to be imported in organization %d, got %d", id, orgID, got)
+		}
+	}
+}
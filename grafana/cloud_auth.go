@@ -0,0 +1,225 @@
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	gapi "github.com/nytm/go-grafana-api"
+)
+
+// azureAuthSchema is the schema of how Azure Monitor data sources
+// authenticate to Azure.
+func azureAuthSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{"json_data_encoded"},
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"managed_identity": &schema.Schema{
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+
+				"cloud_name": &schema.Schema{
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "azuremonitor",
+					ValidateFunc: validateStringIn("azuremonitor", "chinaazuremonitor", "govazuremonitor"),
+				},
+
+				"tenant_id": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+
+				"client_id": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+
+				"client_secret": &schema.Schema{
+					Type:      schema.TypeString,
+					Optional:  true,
+					Sensitive: true,
+				},
+
+				"default_subscription_id": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+			},
+		},
+	}
+}
+
+// googleAuthSchema is the schema of how Google Cloud Monitoring data sources
+// authenticate to Google Cloud.
+func googleAuthSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{"json_data_encoded"},
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"authentication_type": &schema.Schema{
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "jwt",
+					ValidateFunc: validateStringIn("jwt", "gce"),
+				},
+
+				"service_account_key": &schema.Schema{
+					Type:         schema.TypeString,
+					Optional:     true,
+					Sensitive:    true,
+					ValidateFunc: validateServiceAccountKey,
+				},
+
+				"default_project": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+// serviceAccountKey is the part of the JSON key file of a Google Cloud
+// service account that Grafana needs.
+type serviceAccountKey struct {
+	Type        string `json:"type"`
+	ProjectID   string `json:"project_id"`
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+func parseServiceAccountKey(v string) (*serviceAccountKey, error) {
+	key := &serviceAccountKey{}
+	if err := json.Unmarshal([]byte(v), key); err != nil {
+		return nil, err
+	}
+	if key.Type != "service_account" || key.ClientEmail == "" || key.PrivateKey == "" {
+		return nil, fmt.Errorf("it isn't the JSON key file of a service account")
+	}
+	return key, nil
+}
+
+func validateServiceAccountKey(v interface{}, k string) ([]string, []error) {
+	if v.(string) == "" {
+		return nil, nil
+	}
+	if _, err := parseServiceAccountKey(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%s is invalid: %s", k, err)}
+	}
+	return nil, nil
+}
+
+// validateCloudAuth checks that the cloud authentication blocks are only
+// given to data sources of the matching type, with what they require.
+func validateCloudAuth(d *schema.ResourceData) []error {
+	dataSourceType := d.Get("type").(string)
+
+	var errors []error
+	if _, ok := d.GetOk("azure_auth"); ok {
+		if dataSourceType != "grafana-azure-monitor-datasource" {
+			errors = append(errors, fmt.Errorf("azure_auth only applies to data sources of type grafana-azure-monitor-datasource"))
+		}
+		if !d.Get("azure_auth.0.managed_identity").(bool) {
+			for _, name := range []string{"tenant_id", "client_id", "client_secret"} {
+				if d.Get("azure_auth.0."+name).(string) == "" {
+					errors = append(errors, fmt.Errorf("azure_auth.0.%s is required unless azure_auth.0.managed_identity is set", name))
+				}
+			}
+		}
+	}
+	if _, ok := d.GetOk("google_auth"); ok {
+		if dataSourceType != "stackdriver" {
+			errors = append(errors, fmt.Errorf("google_auth only applies to data sources of type stackdriver"))
+		}
+		if d.Get("google_auth.0.authentication_type").(string) == "jwt" && d.Get("google_auth.0.service_account_key").(string) == "" {
+			errors = append(errors, fmt.Errorf("google_auth.0.service_account_key is required when google_auth.0.authentication_type is jwt"))
+		}
+	}
+	return errors
+}
+
+// makeCloudAuth adds the configuration of the cloud authentication blocks to
+// the JSON data and secrets of a data source. The Azure client secret is one
+// of the dataSourceSecrets, and is set along with them.
+func makeCloudAuth(d *schema.ResourceData, jsonData map[string]interface{}, secureJSONData map[string]string) {
+	if _, ok := d.GetOk("azure_auth"); ok {
+		if d.Get("azure_auth.0.managed_identity").(bool) {
+			jsonData["azureAuthType"] = "msi"
+		} else {
+			jsonData["azureAuthType"] = "clientsecret"
+			jsonData["tenantId"] = d.Get("azure_auth.0.tenant_id").(string)
+			jsonData["clientId"] = d.Get("azure_auth.0.client_id").(string)
+		}
+		jsonData["cloudName"] = d.Get("azure_auth.0.cloud_name").(string)
+		if subscriptionID := d.Get("azure_auth.0.default_subscription_id").(string); subscriptionID != "" {
+			jsonData["subscriptionId"] = subscriptionID
+		}
+	}
+
+	if _, ok := d.GetOk("google_auth"); ok {
+		jsonData["authenticationType"] = d.Get("google_auth.0.authentication_type").(string)
+		defaultProject := d.Get("google_auth.0.default_project").(string)
+		if key, err := parseServiceAccountKey(d.Get("google_auth.0.service_account_key").(string)); err == nil {
+			jsonData["clientEmail"] = key.ClientEmail
+			jsonData["tokenUri"] = key.TokenURI
+			secureJSONData["privateKey"] = key.PrivateKey
+			if defaultProject == "" {
+				defaultProject = key.ProjectID
+			}
+		}
+		if defaultProject != "" {
+			jsonData["defaultProject"] = defaultProject
+		}
+	}
+}
+
+// readCloudAuth reads the cloud authentication blocks back from the JSON
+// data of a data source. Their secrets are kept as they were last set, and
+// cleared by readDataSourceSecrets if Grafana no longer has them.
+func readCloudAuth(d *schema.ResourceData, dataSource *gapi.DataSource) {
+	jsonData := dataSource.JSONData
+
+	var azureAuth []interface{}
+	if authType, ok := jsonData["azureAuthType"].(string); ok {
+		subscriptionID, _ := jsonData["subscriptionId"].(string)
+		tenantID, _ := jsonData["tenantId"].(string)
+		clientID, _ := jsonData["clientId"].(string)
+		cloudName, _ := jsonData["cloudName"].(string)
+		azureAuth = []interface{}{map[string]interface{}{
+			"managed_identity":        authType == "msi",
+			"cloud_name":              cloudName,
+			"tenant_id":               tenantID,
+			"client_id":               clientID,
+			"client_secret":           d.Get("azure_auth.0.client_secret").(string),
+			"default_subscription_id": subscriptionID,
+		}}
+	}
+	d.Set("azure_auth", azureAuth)
+
+	var googleAuth []interface{}
+	if authType, ok := jsonData["authenticationType"].(string); ok {
+		defaultProject, _ := jsonData["defaultProject"].(string)
+		key := d.Get("google_auth.0.service_account_key").(string)
+		if dataSource.SecureJSONFields != nil && !dataSource.SecureJSONFields["privateKey"] {
+			key = ""
+		}
+		googleAuth = []interface{}{map[string]interface{}{
+			"authentication_type": authType,
+			"service_account_key": key,
+			"default_project":     defaultProject,
+		}}
+	}
+	d.Set("google_auth", googleAuth)
+}
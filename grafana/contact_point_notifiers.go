@@ -0,0 +1,381 @@
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	gapi "github.com/nytm/go-grafana-api"
+)
+
+// redactedSetting is what Grafana returns in place of the secure settings of
+// contact points.
+const redactedSetting = "[REDACTED]"
+
+// notifierField is a typed setting of a notifier, along with the key Grafana
+// stores it under. Lists are stored as strings joined by their separator.
+type notifierField struct {
+	key       string
+	kind      schema.ValueType
+	secure    bool
+	required  bool
+	separator string
+}
+
+// contactPointNotifiers are the notifier blocks of contact points, along with
+// the integration type Grafana knows them as and their typed settings.
+// Settings that aren't typed can be given in the settings map of each block.
+var contactPointNotifiers = []struct {
+	attribute string
+	typ       string
+	fields    map[string]notifierField
+}{
+	{
+		attribute: "email",
+		typ:       "email",
+		fields: map[string]notifierField{
+			"addresses":    {key: "addresses", kind: schema.TypeList, required: true, separator: ";"},
+			"single_email": {key: "singleEmail", kind: schema.TypeBool},
+			"message":      {key: "message", kind: schema.TypeString},
+			"subject":      {key: "subject", kind: schema.TypeString},
+		},
+	},
+	{
+		attribute: "slack",
+		typ:       "slack",
+		fields: map[string]notifierField{
+			"url":             {key: "url", kind: schema.TypeString, secure: true},
+			"token":           {key: "token", kind: schema.TypeString, secure: true},
+			"recipient":       {key: "recipient", kind: schema.TypeString},
+			"text":            {key: "text", kind: schema.TypeString},
+			"title":           {key: "title", kind: schema.TypeString},
+			"username":        {key: "username", kind: schema.TypeString},
+			"icon_emoji":      {key: "icon_emoji", kind: schema.TypeString},
+			"icon_url":        {key: "icon_url", kind: schema.TypeString},
+			"mention_channel": {key: "mentionChannel", kind: schema.TypeString},
+			"mention_users":   {key: "mentionUsers", kind: schema.TypeList, separator: ","},
+			"mention_groups":  {key: "mentionGroups", kind: schema.TypeList, separator: ","},
+			"endpoint_url":    {key: "endpointUrl", kind: schema.TypeString},
+		},
+	},
+	{
+		attribute: "pagerduty",
+		typ:       "pagerduty",
+		fields: map[string]notifierField{
+			"integration_key": {key: "integrationKey", kind: schema.TypeString, secure: true, required: true},
+			"severity":        {key: "severity", kind: schema.TypeString},
+			"class":           {key: "class", kind: schema.TypeString},
+			"component":       {key: "component", kind: schema.TypeString},
+			"group":           {key: "group", kind: schema.TypeString},
+			"summary":         {key: "summary", kind: schema.TypeString},
+			"source":          {key: "source", kind: schema.TypeString},
+		},
+	},
+	{
+		attribute: "opsgenie",
+		typ:       "opsgenie",
+		fields: map[string]notifierField{
+			"api_key":           {key: "apiKey", kind: schema.TypeString, secure: true, required: true},
+			"url":               {key: "apiUrl", kind: schema.TypeString},
+			"message":           {key: "message", kind: schema.TypeString},
+			"description":       {key: "description", kind: schema.TypeString},
+			"auto_close":        {key: "autoClose", kind: schema.TypeBool},
+			"override_priority": {key: "overridePriority", kind: schema.TypeBool},
+			"send_tags_as":      {key: "sendTagsAs", kind: schema.TypeString},
+		},
+	},
+	{
+		attribute: "webhook",
+		typ:       "webhook",
+		fields: map[string]notifierField{
+			"url":                       {key: "url", kind: schema.TypeString, required: true},
+			"http_method":               {key: "httpMethod", kind: schema.TypeString},
+			"basic_auth_user":           {key: "username", kind: schema.TypeString},
+			"basic_auth_password":       {key: "password", kind: schema.TypeString, secure: true},
+			"authorization_scheme":      {key: "authorization_scheme", kind: schema.TypeString},
+			"authorization_credentials": {key: "authorization_credentials", kind: schema.TypeString, secure: true},
+			"max_alerts":                {key: "maxAlerts", kind: schema.TypeInt},
+		},
+	},
+	{
+		attribute: "teams",
+		typ:       "teams",
+		fields: map[string]notifierField{
+			"url":           {key: "url", kind: schema.TypeString, secure: true, required: true},
+			"title":         {key: "title", kind: schema.TypeString},
+			"section_title": {key: "sectiontitle", kind: schema.TypeString},
+			"message":       {key: "message", kind: schema.TypeString},
+		},
+	},
+	{
+		attribute: "victorops",
+		typ:       "victorops",
+		fields: map[string]notifierField{
+			"url":          {key: "url", kind: schema.TypeString, secure: true, required: true},
+			"message_type": {key: "messageType", kind: schema.TypeString},
+		},
+	},
+	{
+		attribute: "discord",
+		typ:       "discord",
+		fields: map[string]notifierField{
+			"url":                  {key: "url", kind: schema.TypeString, secure: true, required: true},
+			"message":              {key: "message", kind: schema.TypeString},
+			"avatar_url":           {key: "avatar_url", kind: schema.TypeString},
+			"use_discord_username": {key: "use_discord_username", kind: schema.TypeBool},
+		},
+	},
+	{
+		attribute: "telegram",
+		typ:       "telegram",
+		fields: map[string]notifierField{
+			"token":                 {key: "bottoken", kind: schema.TypeString, secure: true, required: true},
+			"chat_id":               {key: "chatid", kind: schema.TypeString, required: true},
+			"message":               {key: "message", kind: schema.TypeString},
+			"parse_mode":            {key: "parse_mode", kind: schema.TypeString},
+			"disable_notifications": {key: "disable_notifications", kind: schema.TypeBool},
+		},
+	},
+	{
+		attribute: "googlechat",
+		typ:       "googlechat",
+		fields: map[string]notifierField{
+			"url":     {key: "url", kind: schema.TypeString, secure: true, required: true},
+			"message": {key: "message", kind: schema.TypeString},
+		},
+	},
+}
+
+// notifierSchema is the schema of the blocks of a notifier, one per
+// integration of the contact point.
+func notifierSchema(fields map[string]notifierField) *schema.Schema {
+	s := map[string]*schema.Schema{
+		"uid": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+
+		"disable_resolve_message": &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"settings": &schema.Schema{
+			Type:      schema.TypeMap,
+			Optional:  true,
+			Sensitive: true,
+			Elem:      &schema.Schema{Type: schema.TypeString},
+		},
+	}
+	for name, field := range fields {
+		fieldSchema := &schema.Schema{
+			Type:      field.kind,
+			Required:  field.required,
+			Optional:  !field.required,
+			Sensitive: field.secure,
+		}
+		if field.kind == schema.TypeList {
+			fieldSchema.Elem = &schema.Schema{Type: schema.TypeString}
+		}
+		s[name] = fieldSchema
+	}
+
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: s,
+		},
+	}
+}
+
+// makeContactPoints returns the integrations of the contact point, in the
+// order of its notifier blocks.
+func makeContactPoints(d *schema.ResourceData) []*gapi.ContactPoint {
+	var points []*gapi.ContactPoint
+	for _, notifier := range contactPointNotifiers {
+		for _, block := range d.Get(notifier.attribute).([]interface{}) {
+			block := block.(map[string]interface{})
+
+			settings := map[string]interface{}{}
+			for key, value := range block["settings"].(map[string]interface{}) {
+				settings[key] = value
+			}
+			for name, field := range notifier.fields {
+				value := block[name]
+				switch field.kind {
+				case schema.TypeList:
+					var values []string
+					for _, v := range value.([]interface{}) {
+						values = append(values, v.(string))
+					}
+					if len(values) > 0 {
+						settings[field.key] = strings.Join(values, field.separator)
+					}
+				case schema.TypeBool:
+					settings[field.key] = value
+				default:
+					if !isEmptyValue(value) {
+						settings[field.key] = value
+					}
+				}
+			}
+
+			points = append(points, &gapi.ContactPoint{
+				Uid:                   block["uid"].(string),
+				Name:                  d.Get("name").(string),
+				Type:                  notifier.typ,
+				Settings:              settings,
+				DisableResolveMessage: block["disable_resolve_message"].(bool),
+			})
+		}
+	}
+	return points
+}
+
+// readContactPoints sets the notifier blocks from the integrations of the
+// contact point. Integrations are kept in the order of the blocks they were
+// read into before, followed by the others. Secure settings, which Grafana
+// redacts, are kept as they were last set, and are cleared once Grafana no
+// longer has them.
+func readContactPoints(d *schema.ResourceData, points []gapi.ContactPoint) error {
+	for _, notifier := range contactPointNotifiers {
+		old := map[string]map[string]interface{}{}
+		position := map[string]int{}
+		for i, block := range d.Get(notifier.attribute).([]interface{}) {
+			if block, ok := block.(map[string]interface{}); ok {
+				old[block["uid"].(string)] = block
+				position[block["uid"].(string)] = i + 1
+			}
+		}
+
+		var matching []gapi.ContactPoint
+		for _, point := range points {
+			if point.Type == notifier.typ {
+				matching = append(matching, point)
+			}
+		}
+		sort.SliceStable(matching, func(i, j int) bool {
+			pi, pj := position[matching[i].Uid], position[matching[j].Uid]
+			return pi != 0 && (pj == 0 || pi < pj)
+		})
+
+		blocks := []interface{}{}
+		for _, point := range matching {
+			oldBlock := old[point.Uid]
+			keep := func(name, key string) interface{} {
+				if oldBlock != nil && point.Settings[key] == redactedSetting {
+					return oldBlock[name]
+				}
+				return nil
+			}
+
+			block := map[string]interface{}{
+				"uid":                     point.Uid,
+				"disable_resolve_message": point.DisableResolveMessage,
+			}
+			typed := map[string]bool{}
+			for name, field := range notifier.fields {
+				typed[field.key] = true
+				value := point.Settings[field.key]
+				if kept := keep(name, field.key); kept != nil {
+					block[name] = kept
+					continue
+				}
+				switch field.kind {
+				case schema.TypeList:
+					block[name] = splitNotifierList(value, field.separator)
+				case schema.TypeBool:
+					block[name] = notifierBool(value)
+				case schema.TypeInt:
+					block[name] = notifierInt(value)
+				default:
+					s, _ := value.(string)
+					if s == redactedSetting {
+						s = ""
+					}
+					block[name] = s
+				}
+			}
+
+			var oldSettings map[string]interface{}
+			if oldBlock != nil {
+				oldSettings, _ = oldBlock["settings"].(map[string]interface{})
+			}
+			settings := map[string]interface{}{}
+			for key, value := range point.Settings {
+				if typed[key] {
+					continue
+				}
+				if value == redactedSetting {
+					if kept, ok := oldSettings[key]; ok {
+						settings[key] = kept
+					}
+					continue
+				}
+				s, err := notifierString(value)
+				if err != nil {
+					return err
+				}
+				settings[key] = s
+			}
+			block["settings"] = settings
+
+			blocks = append(blocks, block)
+		}
+		if err := d.Set(notifier.attribute, blocks); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func splitNotifierList(value interface{}, separator string) []interface{} {
+	s, _ := value.(string)
+	values := []interface{}{}
+	for _, v := range strings.Split(s, separator) {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func notifierBool(value interface{}) bool {
+	switch value := value.(type) {
+	case bool:
+		return value
+	case string:
+		b, _ := strconv.ParseBool(value)
+		return b
+	}
+	return false
+}
+
+func notifierInt(value interface{}) int {
+	switch value := value.(type) {
+	case float64:
+		return int(value)
+	case string:
+		i, _ := strconv.Atoi(value)
+		return i
+	}
+	return 0
+}
+
+// notifierString returns a setting that isn't typed as a string, encoding
+// it as JSON unless it already is one.
+func notifierString(value interface{}) (string, error) {
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("Error encoding contact point setting: %s", err)
+	}
+	return string(encoded), nil
+}
@@ -0,0 +1,93 @@
+package grafana
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// tokenFileRefresh is how long a token read from a file is used before the
+// file is read again, so that tokens rotated by e.g. a Vault agent are
+// picked up by long-running applies.
+const tokenFileRefresh = 5 * time.Minute
+
+// tokenFromFile returns a function reading tokens from the file at path.
+func tokenFromFile(path string) func() (*token, error) {
+	return func() (*token, error) {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		accessToken := strings.TrimSpace(string(data))
+		if accessToken == "" {
+			return nil, fmt.Errorf("%s is empty", path)
+		}
+
+		return &token{accessToken: accessToken, expiry: time.Now().Add(tokenFileRefresh)}, nil
+	}
+}
+
+// tokenFromCommand returns a function obtaining tokens by running a
+// credential helper. The helper prints either the bare token, or a JSON
+// object holding the token and, optionally, the RFC 3339 time it expires at:
+//
+//	{"token": "glsa_...", "expiry": "2018-06-01T12:00:00Z"}
+//
+// Tokens printed bare or without an expiry are refreshed as often as tokens
+// read from a file.
+func tokenFromCommand(command string, args []string, env map[string]string) func() (*token, error) {
+	return func() (*token, error) {
+		cmd := exec.Command(command, args...)
+		cmd.Env = os.Environ()
+		for name, value := range env {
+			cmd.Env = append(cmd.Env, name+"="+value)
+		}
+
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return nil, fmt.Errorf("%s failed: %s: %s", command, err, msg)
+			}
+			return nil, fmt.Errorf("%s failed: %s", command, err)
+		}
+
+		output := bytes.TrimSpace(stdout.Bytes())
+		if len(output) == 0 {
+			return nil, fmt.Errorf("%s printed no token", command)
+		}
+
+		if output[0] != '{' {
+			return &token{accessToken: string(output), expiry: time.Now().Add(tokenFileRefresh)}, nil
+		}
+
+		result := struct {
+			Token  string `json:"token"`
+			Expiry string `json:"expiry"`
+		}{}
+		if err := json.Unmarshal(output, &result); err != nil {
+			return nil, fmt.Errorf("invalid output from %s: %s", command, err)
+		}
+		if result.Token == "" {
+			return nil, fmt.Errorf("%s printed no token", command)
+		}
+
+		tok := &token{accessToken: result.Token, expiry: time.Now().Add(tokenFileRefresh)}
+		if result.Expiry != "" {
+			expiry, err := time.Parse(time.RFC3339, result.Expiry)
+			if err != nil {
+				return nil, fmt.Errorf("invalid expiry %q printed by %s", result.Expiry, command)
+			}
+			tok.expiry = expiry
+		}
+
+		return tok, nil
+	}
+}
@@ -0,0 +1,71 @@
+package grafana
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTokenFromFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "grafana-auth")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "token")
+	fetch := tokenFromFile(path)
+	if _, err := fetch(); err == nil {
+		t.Fatalf("expected an error for a missing file")
+	}
+
+	if err := ioutil.WriteFile(path, []byte("file-token\n"), 0600); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	tok, err := fetch()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if tok.accessToken != "file-token" || !tok.valid() {
+		t.Fatalf("unexpected token %#v", tok)
+	}
+}
+
+func TestTokenFromCommand(t *testing.T) {
+	cases := []struct {
+		script string
+		token  string
+		expiry time.Time
+		err    bool
+	}{
+		{script: `echo bare-token`, token: "bare-token"},
+		{script: `echo '{"token": "json-token", "expiry": "2030-01-02T03:04:05Z"}'`, token: "json-token", expiry: time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{script: `echo "$TOKEN"`, token: "env-token"},
+		{script: `echo '{"expiry": "2030-01-02T03:04:05Z"}'`, err: true},
+		{script: `echo '{"token": "json-token", "expiry": "tomorrow"}'`, err: true},
+		{script: `echo denied >&2; exit 1`, err: true},
+		{script: `true`, err: true},
+	}
+
+	for _, tc := range cases {
+		tok, err := tokenFromCommand("sh", []string{"-c", tc.script}, map[string]string{"TOKEN": "env-token"})()
+		if tc.err {
+			if err == nil {
+				t.Errorf("%s: expected an error, got %#v", tc.script, tok)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: err: %s", tc.script, err)
+			continue
+		}
+		if tok.accessToken != tc.token {
+			t.Errorf("%s: expected token %q, got %q", tc.script, tc.token, tok.accessToken)
+		}
+		if !tc.expiry.IsZero() && !tok.expiry.Equal(tc.expiry) {
+			t.Errorf("%s: expected expiry %s, got %s", tc.script, tc.expiry, tok.expiry)
+		}
+	}
+}
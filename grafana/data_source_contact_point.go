@@ -0,0 +1,51 @@
+package grafana
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func DataSourceContactPoint() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"org_id": orgIDSchema(),
+
+		"name": &schema.Schema{
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	}
+	for _, notifier := range contactPointNotifiers {
+		s[notifier.attribute] = computedSchema(notifierSchema(notifier.fields))
+	}
+
+	return &schema.Resource{
+		Read: dataSourceContactPointRead,
+
+		Schema: s,
+	}
+}
+
+func dataSourceContactPointRead(d *schema.ResourceData, meta interface{}) error {
+	if err := meta.(*client).requireVersion("grafana_contact_point", "9.1.0"); err != nil {
+		return err
+	}
+
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+	points, err := client.ContactPointsByName(name)
+	if err != nil {
+		return accessError(err, fmt.Sprintf("reading contact point %s", name))
+	}
+	if len(points) == 0 {
+		return fmt.Errorf("Contact point %q not found", name)
+	}
+
+	d.SetId(name)
+	// Secure settings are redacted, so they are left empty.
+	return readContactPoints(d, points)
+}
@@ -0,0 +1,96 @@
+package grafana
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestAccDataSourceContactPoint_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccContactPointCheckDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccDataSourceContactPointConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.grafana_contact_point.test", "id", "terraform-acc-test"),
+					resource.TestCheckResourceAttr("data.grafana_contact_point.test", "email.#", "1"),
+					resource.TestCheckResourceAttr("data.grafana_contact_point.test", "email.0.addresses.#", "2"),
+					resource.TestCheckResourceAttr("data.grafana_contact_point.test", "slack.0.recipient", "#alerts"),
+					resource.TestCheckResourceAttrPair(
+						"data.grafana_contact_point.test", "slack.0.uid",
+						"grafana_contact_point.test", "slack.0.uid",
+					),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceContactPointRead(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("name") {
+		case "ops":
+			w.Write([]byte(`[
+				{"uid": "uid-1", "name": "ops", "type": "slack", "settings": {"url": "[REDACTED]", "recipient": "#ops", "mentionUsers": "alice,bob"}},
+				{"uid": "uid-2", "name": "ops", "type": "email", "settings": {"addresses": "ops@example.net"}, "disableResolveMessage": true}
+			]`))
+		default:
+			w.Write([]byte(`[]`))
+		}
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	d := schema.TestResourceDataRaw(t, DataSourceContactPoint().Schema, map[string]interface{}{
+		"name": "ops",
+	})
+	if err := dataSourceContactPointRead(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if d.Id() != "ops" {
+		t.Fatalf("expected id ops, got %q", d.Id())
+	}
+	if url := d.Get("slack.0.url").(string); url != "" {
+		t.Fatalf("expected the redacted url to be left empty, got %q", url)
+	}
+	if users := d.Get("slack.0.mention_users").([]interface{}); len(users) != 2 || users[1] != "bob" {
+		t.Fatalf("expected the mentioned users to be read, got %v", users)
+	}
+	if !d.Get("email.0.disable_resolve_message").(bool) || d.Get("email.0.uid").(string) != "uid-2" {
+		t.Fatalf("unexpected email integration %v", d.Get("email"))
+	}
+
+	d = schema.TestResourceDataRaw(t, DataSourceContactPoint().Schema, map[string]interface{}{
+		"name": "missing",
+	})
+	if err := dataSourceContactPointRead(d, c); err == nil {
+		t.Fatalf("expected an error for a contact point that doesn't exist")
+	}
+}
+
+const testAccDataSourceContactPointConfig_basic = `
+resource "grafana_contact_point" "test" {
+    name = "terraform-acc-test"
+
+    email {
+        addresses = ["one@example.net", "two@example.net"]
+    }
+
+    slack {
+        url       = "https://hooks.slack.test/services/T0/B0/X"
+        recipient = "#alerts"
+    }
+}
+
+data "grafana_contact_point" "test" {
+    name = "${grafana_contact_point.test.name}"
+}
+`
@@ -0,0 +1,132 @@
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func DataSourceDashboard() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDashboardRead,
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDSchema(),
+
+			"uid": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"title"},
+			},
+
+			"title": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"uid"},
+			},
+
+			"folder": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"dashboard_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"slug": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"url": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"config_json": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceDashboardRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	uid := d.Get("uid").(string)
+	if uid == "" {
+		title := d.Get("title").(string)
+		if title == "" {
+			return fmt.Errorf("One of uid or title must be set")
+		}
+
+		// The search API matches titles by substring, in any folder.
+		results, err := searchAll(client, url.Values{
+			"type":  []string{"dash-db"},
+			"query": []string{title},
+		})
+		if err != nil {
+			return accessError(err, "searching dashboards")
+		}
+
+		folder, inFolder := d.GetOk("folder")
+		var uids []string
+		for _, result := range results {
+			if result.Title == title && (!inFolder || result.FolderUid == folder.(string)) {
+				uids = append(uids, result.Uid)
+			}
+		}
+		switch len(uids) {
+		case 0:
+			return fmt.Errorf("Dashboard %q not found", title)
+		case 1:
+			uid = uids[0]
+		default:
+			return fmt.Errorf("Found %d dashboards titled %q: set folder or uid to choose one", len(uids), title)
+		}
+	}
+
+	dashboard, err := client.DashboardByUID(uid)
+	if err != nil {
+		if isNotFound(err) {
+			return fmt.Errorf("Dashboard %s not found", uid)
+		}
+		return accessError(err, fmt.Sprintf("reading dashboard %s", uid))
+	}
+
+	configJSONBytes, err := json.Marshal(dashboard.Model)
+	if err != nil {
+		return err
+	}
+
+	title, _ := dashboard.Model["title"].(string)
+	id, _ := dashboard.Model["id"].(float64)
+
+	d.SetId(uid)
+	d.Set("uid", uid)
+	d.Set("title", title)
+	d.Set("folder", dashboard.Meta.FolderUid)
+	d.Set("dashboard_id", int64(id))
+	d.Set("slug", dashboard.Meta.Slug)
+	d.Set("url", dashboard.Meta.Url)
+	d.Set("version", dashboard.Meta.Version)
+	d.Set("config_json", NormalizeDashboardConfigJSON(string(configJSONBytes)))
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package grafana
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gapi "github.com/nytm/go-grafana-api"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestAccDataSourceDashboard_basic(t *testing.T) {
+	var folder gapi.Folder
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccFolderCheckDestroy(&folder),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccDataSourceDashboardConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccFolderCheckExists("grafana_folder.test", &folder),
+					resource.TestCheckResourceAttrPair(
+						"data.grafana_dashboard.by_uid", "config_json",
+						"grafana_dashboard.test", "config_json",
+					),
+					resource.TestCheckResourceAttrPair(
+						"data.grafana_dashboard.by_title", "uid",
+						"grafana_dashboard.test", "uid",
+					),
+					resource.TestCheckResourceAttr(
+						"data.grafana_dashboard.by_title", "title", "Terraform Acceptance Test Data Source",
+					),
+					resource.TestCheckResourceAttrPair(
+						"data.grafana_dashboard.by_title", "folder",
+						"grafana_folder.test", "uid",
+					),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceDashboardRead_title(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/search":
+			if q := r.URL.Query().Get("query"); q != "Overview" {
+				t.Errorf("unexpected search query %q", q)
+			}
+			if page := r.URL.Query().Get("page"); page != "1" || r.URL.Query().Get("limit") != "1000" {
+				t.Errorf("expected the search to be paginated, got %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`[
+				{"uid": "a", "title": "Overview", "folderUid": "team-a"},
+				{"uid": "b", "title": "Overview", "folderUid": "team-b"},
+				{"uid": "c", "title": "Overview (old)", "folderUid": "team-a"}
+			]`))
+		case "/api/dashboards/uid/b":
+			w.Write([]byte(`{
+				"meta": {"slug": "overview", "url": "/d/b/overview", "folderUid": "team-b", "version": 4},
+				"dashboard": {"id": 2, "uid": "b", "title": "Overview", "version": 4}
+			}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	d := schema.TestResourceDataRaw(t, DataSourceDashboard().Schema, map[string]interface{}{
+		"title": "Overview",
+	})
+	if err := dataSourceDashboardRead(d, c); err == nil || !strings.Contains(err.Error(), "Found 2 dashboards") {
+		t.Fatalf("expected dashboards in different folders to be ambiguous, got %v", err)
+	}
+
+	d = schema.TestResourceDataRaw(t, DataSourceDashboard().Schema, map[string]interface{}{
+		"title":  "Overview",
+		"folder": "team-b",
+	})
+	if err := dataSourceDashboardRead(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Id() != "b" {
+		t.Fatalf("expected dashboard b, got %q", d.Id())
+	}
+	if d.Get("url").(string) != "/d/b/overview" || d.Get("version").(int) != 4 || d.Get("dashboard_id").(int) != 2 {
+		t.Fatalf("unexpected attributes: url %q, version %d, dashboard_id %d", d.Get("url"), d.Get("version"), d.Get("dashboard_id"))
+	}
+	if d.Get("config_json").(string) != `{"title":"Overview"}` {
+		t.Fatalf("unexpected config_json %s", d.Get("config_json"))
+	}
+}
+
+const testAccDataSourceDashboardConfig_basic = `
+resource "grafana_folder" "test" {
+    title = "Terraform Acceptance Test Data Source"
+}
+
+resource "grafana_dashboard" "test" {
+    folder      = "${grafana_folder.test.id}"
+    config_json = <<EOT
+{
+    "title": "Terraform Acceptance Test Data Source"
+}
+EOT
+}
+
+data "grafana_dashboard" "by_uid" {
+    uid = "${grafana_dashboard.test.uid}"
+}
+
+data "grafana_dashboard" "by_title" {
+    title  = "Terraform Acceptance Test Data Source"
+    folder = "${grafana_folder.test.id}"
+
+    depends_on = ["grafana_dashboard.test"]
+}
+`
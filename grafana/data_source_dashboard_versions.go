@@ -0,0 +1,93 @@
+package grafana
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func DataSourceDashboardVersions() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDashboardVersionsRead,
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDSchema(),
+
+			"dashboard_uid": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"versions": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"version": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						"parent_version": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						"restored_from": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						"message": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"created_by": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"created": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDashboardVersionsRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	uid := d.Get("dashboard_uid").(string)
+	results, err := client.DashboardVersions(uid)
+	if err != nil {
+		if isNotFound(err) {
+			return fmt.Errorf("Dashboard %s not found", uid)
+		}
+		return accessError(err, fmt.Sprintf("reading the versions of dashboard %s", uid))
+	}
+
+	versions := make([]interface{}, 0, len(results))
+	for _, result := range results {
+		versions = append(versions, map[string]interface{}{
+			"version":        int(result.Version),
+			"parent_version": int(result.ParentVersion),
+			"restored_from":  int(result.RestoredFrom),
+			"message":        result.Message,
+			"created_by":     result.CreatedBy,
+			"created":        result.Created,
+		})
+	}
+
+	d.SetId(uid)
+	d.Set("versions", versions)
+
+	return nil
+}
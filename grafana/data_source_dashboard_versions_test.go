@@ -0,0 +1,77 @@
+package grafana
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestAccDataSourceDashboardVersions_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccDataSourceDashboardVersionsConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.grafana_dashboard_versions.test", "versions.#", "1"),
+					resource.TestCheckResourceAttr("data.grafana_dashboard_versions.test", "versions.0.version", "1"),
+					resource.TestCheckResourceAttr("data.grafana_dashboard_versions.test", "versions.0.message", "Create the dashboard"),
+					resource.TestCheckResourceAttrSet("data.grafana_dashboard_versions.test", "versions.0.created_by"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceDashboardVersionsRead(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/dashboards/uid/abc123/versions" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		// Grafana 11 and later wrap the versions in an object.
+		w.Write([]byte(`{"versions": [
+			{"version": 3, "parentVersion": 2, "restoredFrom": 1, "message": "", "createdBy": "alice", "created": "2024-05-02T10:00:00Z"},
+			{"version": 2, "parentVersion": 1, "message": "Add panels", "createdBy": "terraform", "created": "2024-05-01T10:00:00Z"}
+		]}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	d := schema.TestResourceDataRaw(t, DataSourceDashboardVersions().Schema, map[string]interface{}{
+		"dashboard_uid": "abc123",
+	})
+	if err := dataSourceDashboardVersionsRead(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Get("versions.#").(int) != 2 {
+		t.Fatalf("expected 2 versions, got %d", d.Get("versions.#"))
+	}
+	if d.Get("versions.0.version").(int) != 3 || d.Get("versions.0.restored_from").(int) != 1 || d.Get("versions.0.created_by").(string) != "alice" {
+		t.Fatalf("unexpected latest version %v", d.Get("versions.0"))
+	}
+	if d.Get("versions.1.message").(string) != "Add panels" {
+		t.Fatalf("unexpected message %q", d.Get("versions.1.message"))
+	}
+}
+
+const testAccDataSourceDashboardVersionsConfig_basic = `
+resource "grafana_dashboard" "test" {
+    message     = "Create the dashboard"
+    config_json = <<EOT
+{
+    "title": "Terraform Acceptance Test Dashboard Versions"
+}
+EOT
+}
+
+data "grafana_dashboard_versions" "test" {
+    dashboard_uid = "${grafana_dashboard.test.uid}"
+}
+`
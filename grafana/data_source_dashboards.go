@@ -0,0 +1,136 @@
+package grafana
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	gapi "github.com/nytm/go-grafana-api"
+)
+
+// searchPerPage is the number of dashboards or folders searched for per
+// request. Grafana only returns the first page of search results unless told
+// otherwise.
+const searchPerPage = 1000
+
+func DataSourceDashboards() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDashboardsRead,
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDSchema(),
+
+			"folder_uids": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"tags": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"query": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"dashboards": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"uid": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"title": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"url": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"folder_uid": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"tags": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDashboardsRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	params := url.Values{
+		"type": []string{"dash-db"},
+	}
+	if query := d.Get("query").(string); query != "" {
+		params.Set("query", query)
+	}
+	for _, uid := range d.Get("folder_uids").([]interface{}) {
+		params.Add("folderUIDs", uid.(string))
+	}
+	for _, tag := range d.Get("tags").([]interface{}) {
+		params.Add("tag", tag.(string))
+	}
+	id := strconv.Itoa(hashcode.String(params.Encode()))
+
+	results, err := searchAll(client, params)
+	if err != nil {
+		return accessError(err, "searching dashboards")
+	}
+
+	dashboards := make([]interface{}, 0, len(results))
+	for _, result := range results {
+		dashboards = append(dashboards, map[string]interface{}{
+			"uid":        result.Uid,
+			"title":      result.Title,
+			"url":        result.Url,
+			"folder_uid": result.FolderUid,
+			"tags":       result.Tags,
+		})
+	}
+
+	d.SetId(id)
+	d.Set("dashboards", dashboards)
+
+	return nil
+}
+
+// searchAll returns all the results of a search, a page at a time.
+func searchAll(client *gapi.Client, params url.Values) ([]gapi.SearchResult, error) {
+	params.Set("limit", strconv.Itoa(searchPerPage))
+
+	var results []gapi.SearchResult
+	for page := 1; ; page++ {
+		params.Set("page", strconv.Itoa(page))
+		pageResults, err := client.Search(params)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, pageResults...)
+		if len(pageResults) < searchPerPage {
+			return results, nil
+		}
+	}
+}
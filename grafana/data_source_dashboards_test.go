@@ -0,0 +1,137 @@
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	gapi "github.com/nytm/go-grafana-api"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestAccDataSourceDashboards_basic(t *testing.T) {
+	var folder gapi.Folder
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccFolderCheckDestroy(&folder),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccDataSourceDashboardsConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccFolderCheckExists("grafana_folder.test", &folder),
+					resource.TestCheckResourceAttr(
+						"data.grafana_dashboards.folder", "dashboards.#", "2",
+					),
+					resource.TestCheckResourceAttr(
+						"data.grafana_dashboards.tagged", "dashboards.#", "1",
+					),
+					resource.TestCheckResourceAttrPair(
+						"data.grafana_dashboards.tagged", "dashboards.0.uid",
+						"grafana_dashboard.tagged", "uid",
+					),
+					resource.TestCheckResourceAttr(
+						"data.grafana_dashboards.tagged", "dashboards.0.tags.0", "tf-acc-test",
+					),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceDashboardsRead_paginated(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("type") != "dash-db" || query.Get("query") != "ops" ||
+			!reflect.DeepEqual(query["folderUIDs"], []string{"a", "b"}) ||
+			!reflect.DeepEqual(query["tag"], []string{"prod"}) {
+			t.Errorf("unexpected search %s", r.URL.RawQuery)
+		}
+
+		var page []gapi.SearchResult
+		switch query.Get("page") {
+		case "1":
+			for i := 0; i < searchPerPage; i++ {
+				page = append(page, gapi.SearchResult{Uid: fmt.Sprintf("d%d", i)})
+			}
+		case "2":
+			page = []gapi.SearchResult{
+				{Uid: "last", Title: "Last", Url: "/d/last/last", FolderUid: "b", Tags: []string{"prod"}},
+			}
+		default:
+			t.Errorf("unexpected page %s", query.Get("page"))
+		}
+		json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	d := schema.TestResourceDataRaw(t, DataSourceDashboards().Schema, map[string]interface{}{
+		"query":       "ops",
+		"folder_uids": []interface{}{"a", "b"},
+		"tags":        []interface{}{"prod"},
+	})
+	if err := dataSourceDashboardsRead(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if n := d.Get("dashboards.#").(int); n != searchPerPage+1 {
+		t.Fatalf("expected %d dashboards, got %d", searchPerPage+1, n)
+	}
+	last := d.Get(fmt.Sprintf("dashboards.%d", searchPerPage)).(map[string]interface{})
+	expected := map[string]interface{}{
+		"uid":        "last",
+		"title":      "Last",
+		"url":        "/d/last/last",
+		"folder_uid": "b",
+		"tags":       []interface{}{"prod"},
+	}
+	if !reflect.DeepEqual(last, expected) {
+		t.Fatalf("expected %v, got %v", expected, last)
+	}
+}
+
+const testAccDataSourceDashboardsConfig_basic = `
+resource "grafana_folder" "test" {
+    title = "Terraform Acceptance Test Dashboards"
+}
+
+resource "grafana_dashboard" "tagged" {
+    folder      = "${grafana_folder.test.id}"
+    config_json = <<EOT
+{
+    "title": "Terraform Acceptance Test Tagged",
+    "tags": ["tf-acc-test"]
+}
+EOT
+}
+
+resource "grafana_dashboard" "untagged" {
+    folder      = "${grafana_folder.test.id}"
+    config_json = <<EOT
+{
+    "title": "Terraform Acceptance Test Untagged"
+}
+EOT
+}
+
+data "grafana_dashboards" "folder" {
+    folder_uids = ["${grafana_folder.test.id}"]
+
+    depends_on = ["grafana_dashboard.tagged", "grafana_dashboard.untagged"]
+}
+
+data "grafana_dashboards" "tagged" {
+    folder_uids = ["${grafana_folder.test.id}"]
+    tags        = ["tf-acc-test"]
+
+    depends_on = ["grafana_dashboard.tagged", "grafana_dashboard.untagged"]
+}
+`
@@ -0,0 +1,93 @@
+package grafana
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	gapi "github.com/nytm/go-grafana-api"
+)
+
+func DataSourceDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDataSourceRead,
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDSchema(),
+
+			"uid": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"name"},
+			},
+
+			"name": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"uid"},
+			},
+
+			"type": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"url": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"is_default": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"access_mode": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"database_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	var dataSource *gapi.DataSource
+	uid, name := d.Get("uid").(string), d.Get("name").(string)
+	switch {
+	case uid != "":
+		dataSource, err = client.DataSourceByUID(uid)
+	case name != "":
+		dataSource, err = client.DataSourceByName(name)
+	default:
+		return fmt.Errorf("One of uid or name must be set")
+	}
+	if err != nil {
+		if isNotFound(err) {
+			return fmt.Errorf("Data source %s not found", uid+name)
+		}
+		return accessError(err, fmt.Sprintf("reading data source %s", uid+name))
+	}
+
+	d.SetId(strconv.FormatInt(dataSource.Id, 10))
+	d.Set("uid", dataSource.Uid)
+	d.Set("name", dataSource.Name)
+	d.Set("type", dataSource.Type)
+	d.Set("url", dataSource.URL)
+	d.Set("is_default", dataSource.IsDefault)
+	d.Set("access_mode", dataSource.Access)
+	d.Set("database_name", dataSource.Database)
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package grafana
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gapi "github.com/nytm/go-grafana-api"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceDataSource_basic(t *testing.T) {
+	var dataSource gapi.DataSource
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccDataSourceCheckDestroy(&dataSource),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccDataSourceDataSourceConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceCheckExists("grafana_data_source.test", &dataSource),
+					resource.TestCheckResourceAttrPair(
+						"data.grafana_data_source.by_name", "id",
+						"grafana_data_source.test", "id",
+					),
+					resource.TestCheckResourceAttr(
+						"data.grafana_data_source.by_name", "uid", "tf-acc-test-ds-lookup",
+					),
+					resource.TestCheckResourceAttr(
+						"data.grafana_data_source.by_uid", "name", "terraform-acc-test-ds-lookup",
+					),
+					resource.TestCheckResourceAttr(
+						"data.grafana_data_source.by_uid", "type", "prometheus",
+					),
+					resource.TestCheckResourceAttr(
+						"data.grafana_data_source.by_uid", "url", "http://terraform-acc-test.invalid/",
+					),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceByName_escaping(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.EscapedPath(), "/api/datasources/name/") {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		name := strings.TrimPrefix(r.URL.Path, "/api/datasources/name/")
+		json.NewEncoder(w).Encode(gapi.DataSource{Id: 1, Name: name})
+	}))
+	defer server.Close()
+
+	client, err := gapi.New("admin:admin", server.URL)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	for _, name := range []string{"prometheus/eu-1", "what?", "../admin", "100% uptime"} {
+		dataSource, err := client.DataSourceByName(name)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if dataSource.Name != name {
+			t.Errorf("expected data source %q to be requested, got %q", name, dataSource.Name)
+		}
+	}
+}
+
+const testAccDataSourceDataSourceConfig_basic = `
+resource "grafana_data_source" "test" {
+    uid  = "tf-acc-test-ds-lookup"
+    type = "prometheus"
+    name = "terraform-acc-test-ds-lookup"
+    url  = "http://terraform-acc-test.invalid/"
+}
+
+data "grafana_data_source" "by_name" {
+    name = "${grafana_data_source.test.name}"
+}
+
+data "grafana_data_source" "by_uid" {
+    uid = "${grafana_data_source.test.uid}"
+}
+`
@@ -0,0 +1,94 @@
+package grafana
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func DataSourceDataSources() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDataSourcesRead,
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDSchema(),
+
+			"type": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"data_sources": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						"uid": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"url": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"is_default": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDataSourcesRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	results, err := client.DataSources()
+	if err != nil {
+		return accessError(err, "listing data sources")
+	}
+
+	// The data sources API can't filter by type.
+	dataSourceType := d.Get("type").(string)
+	dataSources := make([]interface{}, 0, len(results))
+	for _, result := range results {
+		if dataSourceType != "" && result.Type != dataSourceType {
+			continue
+		}
+		dataSources = append(dataSources, map[string]interface{}{
+			"id":         int(result.Id),
+			"uid":        result.Uid,
+			"name":       result.Name,
+			"type":       result.Type,
+			"url":        result.URL,
+			"is_default": result.IsDefault,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%d/%s", d.Get("org_id").(int), dataSourceType))
+	d.Set("data_sources", dataSources)
+
+	return nil
+}
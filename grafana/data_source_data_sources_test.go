@@ -0,0 +1,80 @@
+package grafana
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestAccDataSourceDataSources_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccDataSourceDataSourcesConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.grafana_data_sources.loki", "data_sources.#", "1"),
+					resource.TestCheckResourceAttr("data.grafana_data_sources.loki", "data_sources.0.uid", "tf-acc-test-ds-list"),
+					resource.TestCheckResourceAttr("data.grafana_data_sources.loki", "data_sources.0.type", "loki"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceDataSourcesRead(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/datasources" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`[
+			{"id": 1, "uid": "prom-eu", "name": "Prometheus EU", "type": "prometheus", "url": "http://prometheus-eu", "isDefault": true},
+			{"id": 2, "uid": "loki", "name": "Loki", "type": "loki", "url": "http://loki"},
+			{"id": 3, "uid": "prom-us", "name": "Prometheus US", "type": "prometheus", "url": "http://prometheus-us"}
+		]`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	d := schema.TestResourceDataRaw(t, DataSourceDataSources().Schema, map[string]interface{}{})
+	if err := dataSourceDataSourcesRead(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Get("data_sources.#").(int) != 3 {
+		t.Fatalf("expected 3 data sources, got %d", d.Get("data_sources.#"))
+	}
+
+	d = schema.TestResourceDataRaw(t, DataSourceDataSources().Schema, map[string]interface{}{
+		"type": "prometheus",
+	})
+	if err := dataSourceDataSourcesRead(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Get("data_sources.#").(int) != 2 {
+		t.Fatalf("expected 2 prometheus data sources, got %d", d.Get("data_sources.#"))
+	}
+	if d.Get("data_sources.0.uid").(string) != "prom-eu" || !d.Get("data_sources.0.is_default").(bool) || d.Get("data_sources.1.id").(int) != 3 {
+		t.Fatalf("unexpected data sources %v", d.Get("data_sources"))
+	}
+}
+
+const testAccDataSourceDataSourcesConfig_basic = `
+resource "grafana_data_source" "test" {
+    uid  = "tf-acc-test-ds-list"
+    type = "loki"
+    name = "terraform-acc-test-ds-list"
+    url  = "http://terraform-acc-test.invalid/"
+}
+
+data "grafana_data_sources" "loki" {
+    type       = "loki"
+    depends_on = ["grafana_data_source.test"]
+}
+`
@@ -0,0 +1,97 @@
+package grafana
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func DataSourceFolder() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceFolderRead,
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDSchema(),
+
+			"uid": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"title"},
+			},
+
+			"title": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"uid"},
+			},
+
+			"folder_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"url": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceFolderRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	uid := d.Get("uid").(string)
+	if uid == "" {
+		title := d.Get("title").(string)
+		if title == "" {
+			return fmt.Errorf("One of uid or title must be set")
+		}
+
+		// The search API matches titles by substring.
+		results, err := client.Search(url.Values{
+			"type":  []string{"dash-folder"},
+			"query": []string{title},
+		})
+		if err != nil {
+			return accessError(err, "searching folders")
+		}
+
+		var uids []string
+		for _, result := range results {
+			if result.Title == title {
+				uids = append(uids, result.Uid)
+			}
+		}
+		switch len(uids) {
+		case 0:
+			return fmt.Errorf("Folder %q not found", title)
+		case 1:
+			uid = uids[0]
+		default:
+			return fmt.Errorf("Found %d folders titled %q: set uid to choose one", len(uids), title)
+		}
+	}
+
+	folder, err := client.Folder(uid)
+	if err != nil {
+		if isNotFound(err) {
+			return fmt.Errorf("Folder %s not found", uid)
+		}
+		return accessError(err, fmt.Sprintf("reading folder %s", uid))
+	}
+
+	d.SetId(folder.Uid)
+	d.Set("uid", folder.Uid)
+	d.Set("title", folder.Title)
+	d.Set("folder_id", folder.Id)
+	d.Set("url", folder.Url)
+
+	return nil
+}
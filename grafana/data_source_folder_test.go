@@ -0,0 +1,56 @@
+package grafana
+
+import (
+	"testing"
+
+	gapi "github.com/nytm/go-grafana-api"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceFolder_basic(t *testing.T) {
+	var folder gapi.Folder
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccFolderCheckDestroy(&folder),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccDataSourceFolderConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccFolderCheckExists("grafana_folder.test", &folder),
+					resource.TestCheckResourceAttrPair(
+						"data.grafana_folder.by_title", "uid",
+						"grafana_folder.test", "uid",
+					),
+					resource.TestCheckResourceAttrPair(
+						"data.grafana_folder.by_title", "folder_id",
+						"grafana_folder.test", "folder_id",
+					),
+					resource.TestCheckResourceAttr(
+						"data.grafana_folder.by_uid", "title", "Terraform Acceptance Test Folder Data Source",
+					),
+					resource.TestCheckResourceAttr(
+						"data.grafana_folder.by_uid", "url", "/dashboards/f/tf-acc-test-folder-ds/terraform-acceptance-test-folder-data-source",
+					),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceFolderConfig_basic = `
+resource "grafana_folder" "test" {
+    title = "Terraform Acceptance Test Folder Data Source"
+    uid   = "tf-acc-test-folder-ds"
+}
+
+data "grafana_folder" "by_title" {
+    title = "${grafana_folder.test.title}"
+}
+
+data "grafana_folder" "by_uid" {
+    uid = "${grafana_folder.test.uid}"
+}
+`
@@ -0,0 +1,82 @@
+package grafana
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func DataSourceFolders() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceFoldersRead,
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDSchema(),
+
+			"folders": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"folder_id": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						"uid": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"title": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"url": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"parent_folder_uid": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceFoldersRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	// Unlike the folders API, the search API also returns nested folders.
+	results, err := searchAll(client, url.Values{
+		"type": []string{"dash-folder"},
+	})
+	if err != nil {
+		return accessError(err, "searching folders")
+	}
+
+	folders := make([]interface{}, 0, len(results))
+	for _, result := range results {
+		folders = append(folders, map[string]interface{}{
+			"folder_id":         int(result.Id),
+			"uid":               result.Uid,
+			"title":             result.Title,
+			"url":               result.Url,
+			"parent_folder_uid": result.FolderUid,
+		})
+	}
+
+	d.SetId(strconv.Itoa(d.Get("org_id").(int)))
+	d.Set("folders", folders)
+
+	return nil
+}
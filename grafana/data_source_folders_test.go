@@ -0,0 +1,60 @@
+package grafana
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	gapi "github.com/nytm/go-grafana-api"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccDataSourceFolders_basic(t *testing.T) {
+	var folder gapi.Folder
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccFolderCheckDestroy(&folder),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccDataSourceFoldersConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccFolderCheckExists("grafana_folder.test", &folder),
+					testAccDataSourceFoldersCheckListed("data.grafana_folders.all", "tf-acc-test-folders"),
+				),
+			},
+		},
+	})
+}
+
+// testAccDataSourceFoldersCheckListed checks that the folder with the given
+// UID is listed by a grafana_folders data source.
+func testAccDataSourceFoldersCheckListed(rn, uid string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		for k, v := range rs.Primary.Attributes {
+			if strings.HasPrefix(k, "folders.") && strings.HasSuffix(k, ".uid") && v == uid {
+				return nil
+			}
+		}
+		return fmt.Errorf("folder %s not listed in %s", uid, rn)
+	}
+}
+
+const testAccDataSourceFoldersConfig_basic = `
+resource "grafana_folder" "test" {
+    title = "Terraform Acceptance Test Folders"
+    uid   = "tf-acc-test-folders"
+}
+
+data "grafana_folders" "all" {
+    depends_on = ["grafana_folder.test"]
+}
+`
@@ -0,0 +1,101 @@
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	gapi "github.com/nytm/go-grafana-api"
+)
+
+func DataSourceLibraryPanel() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceLibraryPanelRead,
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDSchema(),
+
+			"uid": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"name"},
+			},
+
+			"name": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"uid"},
+			},
+
+			"folder": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"panel_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"model_json": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceLibraryPanelRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	var panel *gapi.LibraryPanel
+	if uid := d.Get("uid").(string); uid != "" {
+		panel, err = client.LibraryPanel(uid)
+		if err != nil {
+			if isNotFound(err) {
+				return fmt.Errorf("Library panel %s not found", uid)
+			}
+			return accessError(err, fmt.Sprintf("reading library panel %s", uid))
+		}
+	} else if name := d.Get("name").(string); name != "" {
+		panels, err := client.LibraryPanelsByName(name)
+		if err != nil && !isNotFound(err) {
+			return accessError(err, fmt.Sprintf("reading library panel %s", name))
+		}
+		switch len(panels) {
+		case 0:
+			return fmt.Errorf("Library panel %q not found", name)
+		case 1:
+			panel = &panels[0]
+		default:
+			return fmt.Errorf("Found %d library panels named %q: set uid to choose one", len(panels), name)
+		}
+	} else {
+		return fmt.Errorf("One of uid or name must be set")
+	}
+
+	modelJSON, err := json.Marshal(panel.Model)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(panel.Uid)
+	d.Set("uid", panel.Uid)
+	d.Set("name", panel.Name)
+	d.Set("folder", panel.FolderUid)
+	d.Set("panel_id", panel.Id)
+	d.Set("version", panel.Version)
+	d.Set("model_json", normalizeLibraryPanelModelJSON(string(modelJSON)))
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package grafana
+
+import (
+	"testing"
+
+	gapi "github.com/nytm/go-grafana-api"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceLibraryPanel_basic(t *testing.T) {
+	var panel gapi.LibraryPanel
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccLibraryPanelCheckDestroy(&panel),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccDataSourceLibraryPanelConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccLibraryPanelCheckExists("grafana_library_panel.test", &panel),
+					resource.TestCheckResourceAttrPair(
+						"data.grafana_library_panel.by_name", "uid",
+						"grafana_library_panel.test", "uid",
+					),
+					resource.TestCheckResourceAttrPair(
+						"data.grafana_library_panel.by_uid", "model_json",
+						"grafana_library_panel.test", "model_json",
+					),
+					resource.TestCheckResourceAttrPair(
+						"data.grafana_library_panel.by_uid", "version",
+						"grafana_library_panel.test", "version",
+					),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceLibraryPanelConfig_basic = `
+resource "grafana_library_panel" "test" {
+    name       = "Terraform Acceptance Test Panel Data Source"
+    model_json = <<EOT
+{
+    "type": "text",
+    "title": "Terraform Acceptance Test Panel Data Source"
+}
+EOT
+}
+
+data "grafana_library_panel" "by_name" {
+    name = "${grafana_library_panel.test.name}"
+}
+
+data "grafana_library_panel" "by_uid" {
+    uid = "${grafana_library_panel.test.uid}"
+}
+`
@@ -0,0 +1,104 @@
+package grafana
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	gapi "github.com/nytm/go-grafana-api"
+)
+
+func DataSourceOrganization() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceOrganizationRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"org_id"},
+			},
+
+			"org_id": &schema.Schema{
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"name"},
+			},
+
+			"admins": &schema.Schema{
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"editors": &schema.Schema{
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"viewers": &schema.Schema{
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"users_without_access": &schema.Schema{
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+		},
+	}
+}
+
+func dataSourceOrganizationRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*client).forOrg(0)
+	if err != nil {
+		return err
+	}
+
+	var org *gapi.Org
+	if orgID := int64(d.Get("org_id").(int)); orgID != 0 {
+		org, err = client.Org(orgID)
+	} else if name := d.Get("name").(string); name != "" {
+		org, err = client.OrgByName(name)
+	} else {
+		return fmt.Errorf("One of name or org_id must be set")
+	}
+	if err != nil {
+		if isNotFound(err) {
+			return fmt.Errorf("Organization not found")
+		}
+		return accessError(err, "reading organization")
+	}
+
+	orgUsers, err := client.OrgUsers(org.Id)
+	if err != nil {
+		return accessError(err, fmt.Sprintf("reading users of organization %d", org.Id))
+	}
+
+	usersByRole := map[string][]string{}
+	for _, orgUser := range orgUsers {
+		user := orgUser.Email
+		if user == "" {
+			user = orgUser.Login
+		}
+		usersByRole[orgUser.Role] = append(usersByRole[orgUser.Role], user)
+	}
+
+	d.SetId(strconv.FormatInt(org.Id, 10))
+	d.Set("name", org.Name)
+	d.Set("org_id", org.Id)
+	for _, r := range orgRoles {
+		d.Set(r.attribute, usersByRole[r.role])
+	}
+
+	return nil
+}
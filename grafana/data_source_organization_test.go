@@ -0,0 +1,51 @@
+package grafana
+
+import (
+	"testing"
+
+	gapi "github.com/nytm/go-grafana-api"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceOrganization_basic(t *testing.T) {
+	var org gapi.Org
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccOrganizationCheckDestroy(&org),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccDataSourceOrganizationConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccOrganizationCheckExists("grafana_organization.test", &org),
+					resource.TestCheckResourceAttrPair(
+						"data.grafana_organization.by_name", "org_id",
+						"grafana_organization.test", "org_id",
+					),
+					resource.TestCheckResourceAttr(
+						"data.grafana_organization.by_id", "name", "terraform-acc-test-data-source",
+					),
+					resource.TestCheckResourceAttr(
+						"data.grafana_organization.by_id", "admins.#", "1",
+					),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceOrganizationConfig_basic = `
+resource "grafana_organization" "test" {
+    name = "terraform-acc-test-data-source"
+}
+
+data "grafana_organization" "by_name" {
+    name = "${grafana_organization.test.name}"
+}
+
+data "grafana_organization" "by_id" {
+    org_id = "${grafana_organization.test.org_id}"
+}
+`
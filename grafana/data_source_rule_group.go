@@ -0,0 +1,67 @@
+package grafana
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func DataSourceRuleGroup() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceRuleGroupRead,
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDSchema(),
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"folder_uid": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"interval_seconds": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"rule": computedSchema(ResourceRuleGroup().Schema["rule"]),
+		},
+	}
+}
+
+func dataSourceRuleGroupRead(d *schema.ResourceData, meta interface{}) error {
+	if err := meta.(*client).requireVersion("grafana_rule_group", "9.4.0"); err != nil {
+		return err
+	}
+
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	folderUID, name := d.Get("folder_uid").(string), d.Get("name").(string)
+	group, err := client.AlertRuleGroup(folderUID, name)
+	if err != nil && !isNotFound(err) {
+		return accessError(err, fmt.Sprintf("reading rule group %s", name))
+	}
+	if err != nil || len(group.Rules) == 0 {
+		return fmt.Errorf("Rule group %q not found in folder %s", name, folderUID)
+	}
+
+	rules, err := readAlertRules(group.Rules)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", folderUID, name))
+	d.Set("interval_seconds", int(group.Interval))
+	if err := d.Set("rule", rules); err != nil {
+		return err
+	}
+
+	return nil
+}
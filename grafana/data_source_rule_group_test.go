@@ -0,0 +1,37 @@
+package grafana
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceRuleGroup_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccRuleGroupCheckDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccRuleGroupConfig("5m") + testAccDataSourceRuleGroupConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.grafana_rule_group.test", "interval_seconds", "60"),
+					resource.TestCheckResourceAttr("data.grafana_rule_group.test", "rule.#", "1"),
+					resource.TestCheckResourceAttr("data.grafana_rule_group.test", "rule.0.data.#", "2"),
+					resource.TestCheckResourceAttr("data.grafana_rule_group.test", "rule.0.labels.severity", "warning"),
+					resource.TestCheckResourceAttrPair(
+						"data.grafana_rule_group.test", "rule.0.uid",
+						"grafana_rule_group.test", "rule.0.uid",
+					),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceRuleGroupConfig_basic = `
+data "grafana_rule_group" "test" {
+    name       = "${grafana_rule_group.test.name}"
+    folder_uid = "${grafana_rule_group.test.folder_uid}"
+}
+`
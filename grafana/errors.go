@@ -0,0 +1,55 @@
+package grafana
+
+import (
+	"fmt"
+	"net/http"
+
+	gapi "github.com/nytm/go-grafana-api"
+)
+
+// statusCode returns the HTTP status code of a failed Grafana API request,
+// or zero if err didn't come from an API response at all.
+func statusCode(err error) int {
+	if e, ok := err.(*gapi.StatusError); ok {
+		return e.StatusCode
+	}
+	return 0
+}
+
+// statusReason returns the status a Grafana API response gives in its body
+// to tell failures with the same status code apart, if any.
+func statusReason(err error) string {
+	if e, ok := err.(*gapi.StatusError); ok {
+		return e.Reason
+	}
+	return ""
+}
+
+// isNotFound reports whether err is the Grafana API's response to a request
+// for something that doesn't exist.
+func isNotFound(err error) bool {
+	return statusCode(err) == http.StatusNotFound
+}
+
+// accessError explains authentication and authorization failures, which
+// point at the provider's credentials rather than at the resource being
+// managed. Other errors are returned unchanged.
+func accessError(err error, action string) error {
+	switch statusCode(err) {
+	case http.StatusUnauthorized:
+		return fmt.Errorf("Error %s: %s (check the credentials given in the provider's auth argument)", action, err)
+	case http.StatusForbidden:
+		return fmt.Errorf("Error %s: %s (the provider's credentials are not allowed to do this; an Admin role is usually required)", action, err)
+	}
+	return err
+}
+
+// actionError is like accessError, but also names the action for errors
+// that don't point at the provider's credentials. It is used where the
+// errors of several actions are reported together.
+func actionError(err error, action string) error {
+	if e := accessError(err, action); e != err {
+		return e
+	}
+	return fmt.Errorf("Error %s: %s", action, err)
+}
@@ -0,0 +1,56 @@
+package grafana
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gapi "github.com/nytm/go-grafana-api"
+)
+
+func TestAPIErrors(t *testing.T) {
+	status := http.StatusNotFound
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(`{"message": "Data source not found"}`))
+	}))
+	defer server.Close()
+
+	client, err := gapi.New("api-key", server.URL)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	_, err = client.DataSource(1)
+	if !isNotFound(err) {
+		t.Fatalf("expected a not found error, got %#v", err)
+	}
+	if err.Error() != "404 Not Found: Data source not found" {
+		t.Fatalf("expected the API message to be included, got %q", err.Error())
+	}
+	if accessError(err, "reading data source 1") != err {
+		t.Fatalf("expected not found errors to be returned unchanged")
+	}
+	if msg := actionError(err, "reading data source 1").Error(); msg != "Error reading data source 1: 404 Not Found: Data source not found" {
+		t.Fatalf("expected the action to be named, got %q", msg)
+	}
+
+	for _, status = range []int{http.StatusUnauthorized, http.StatusForbidden} {
+		_, err = client.DataSource(1)
+		if isNotFound(err) {
+			t.Fatalf("expected %d not to be treated as not found", status)
+		}
+		if msg := accessError(err, "reading data source 1").Error(); !strings.Contains(msg, "provider") {
+			t.Fatalf("expected %d to point at the provider credentials, got %q", status, msg)
+		}
+		if msg := actionError(err, "reading data source 1").Error(); !strings.Contains(msg, "provider") {
+			t.Fatalf("expected %d to point at the provider credentials, got %q", status, msg)
+		}
+	}
+
+	if isNotFound(errors.New("404 Not Found")) {
+		t.Fatalf("expected untyped errors not to be treated as API responses")
+	}
+}
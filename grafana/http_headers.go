@@ -0,0 +1,77 @@
+package grafana
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	gapi "github.com/nytm/go-grafana-api"
+)
+
+// httpHeaderSchema is the schema of the custom HTTP headers data sources
+// send with their requests, e.g. to authenticate with a bearer token.
+func httpHeaderSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		ConflictsWith: []string{"json_data_encoded"},
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": &schema.Schema{
+					Type:     schema.TypeString,
+					Required: true,
+				},
+
+				"value": &schema.Schema{
+					Type:      schema.TypeString,
+					Required:  true,
+					Sensitive: true,
+				},
+			},
+		},
+	}
+}
+
+// makeHTTPHeaders adds the custom HTTP headers to a data source the way
+// Grafana stores them: their names as httpHeaderName1, httpHeaderName2...
+// in JSON data, and their values as the matching httpHeaderValue secrets.
+func makeHTTPHeaders(d *schema.ResourceData, jsonData map[string]interface{}, secureJSONData map[string]string) {
+	oldHeaders, newHeaders := d.GetChange("http_header")
+	for i, header := range newHeaders.([]interface{}) {
+		header := header.(map[string]interface{})
+		jsonData[fmt.Sprintf("httpHeaderName%d", i+1)] = header["name"].(string)
+		secureJSONData[fmt.Sprintf("httpHeaderValue%d", i+1)] = header["value"].(string)
+	}
+	// The values of removed headers are cleared, rather than left behind
+	// for the next header added in their place.
+	for i := len(newHeaders.([]interface{})); i < len(oldHeaders.([]interface{})); i++ {
+		secureJSONData[fmt.Sprintf("httpHeaderValue%d", i+1)] = ""
+	}
+}
+
+// readHTTPHeaders reads the names of the custom HTTP headers back from the
+// JSON data of a data source. Their values are kept as they were last set,
+// unless Grafana no longer has them.
+func readHTTPHeaders(d *schema.ResourceData, dataSource *gapi.DataSource) {
+	values := map[string]string{}
+	for _, header := range d.Get("http_header").([]interface{}) {
+		header := header.(map[string]interface{})
+		values[header["name"].(string)] = header["value"].(string)
+	}
+
+	var headers []interface{}
+	for i := 1; ; i++ {
+		name, ok := dataSource.JSONData[fmt.Sprintf("httpHeaderName%d", i)].(string)
+		if !ok {
+			break
+		}
+		value := values[name]
+		if dataSource.SecureJSONFields != nil && !dataSource.SecureJSONFields[fmt.Sprintf("httpHeaderValue%d", i)] {
+			value = ""
+		}
+		headers = append(headers, map[string]interface{}{
+			"name":  name,
+			"value": value,
+		})
+	}
+	d.Set("http_header", headers)
+}
@@ -0,0 +1,65 @@
+package grafana
+
+import (
+	"reflect"
+	"testing"
+
+	gapi "github.com/nytm/go-grafana-api"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestMakeHTTPHeaders(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, ResourceDataSource().Schema, map[string]interface{}{
+		"type": "prometheus",
+		"http_header": []interface{}{
+			map[string]interface{}{"name": "Authorization", "value": "Bearer token"},
+			map[string]interface{}{"name": "X-Scope-OrgID", "value": "tenant"},
+		},
+	})
+	dataSource, err := makeDataSource(d)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expectedJSONData := map[string]interface{}{
+		"httpHeaderName1": "Authorization",
+		"httpHeaderName2": "X-Scope-OrgID",
+	}
+	if !reflect.DeepEqual(dataSource.JSONData, expectedJSONData) {
+		t.Errorf("expected JSON data %v, got %v", expectedJSONData, dataSource.JSONData)
+	}
+	expectedSecrets := map[string]string{
+		"httpHeaderValue1": "Bearer token",
+		"httpHeaderValue2": "tenant",
+	}
+	if !reflect.DeepEqual(dataSource.SecureJSONData, expectedSecrets) {
+		t.Errorf("expected secrets %v, got %v", expectedSecrets, dataSource.SecureJSONData)
+	}
+}
+
+func TestReadHTTPHeaders(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, ResourceDataSource().Schema, map[string]interface{}{
+		"type": "prometheus",
+		"http_header": []interface{}{
+			map[string]interface{}{"name": "Authorization", "value": "Bearer token"},
+			map[string]interface{}{"name": "X-Scope-OrgID", "value": "tenant"},
+		},
+	})
+	// The headers were reordered in Grafana, and the value of one was reset.
+	readHTTPHeaders(d, &gapi.DataSource{
+		JSONData: map[string]interface{}{
+			"httpHeaderName1": "X-Scope-OrgID",
+			"httpHeaderName2": "Authorization",
+		},
+		SecureJSONFields: map[string]bool{"httpHeaderValue1": true},
+	})
+
+	expected := []interface{}{
+		map[string]interface{}{"name": "X-Scope-OrgID", "value": "tenant"},
+		map[string]interface{}{"name": "Authorization", "value": ""},
+	}
+	if headers := d.Get("http_header").([]interface{}); !reflect.DeepEqual(headers, expected) {
+		t.Fatalf("expected headers %v, got %v", expected, headers)
+	}
+}
@@ -0,0 +1,30 @@
+package grafana
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// oauth2ClientCredentials returns a function fetching tokens from an OAuth2
+// token endpoint with the client credentials grant.
+func oauth2ClientCredentials(httpClient *http.Client, tokenURL, clientID, clientSecret string, scopes []string) func() (*token, error) {
+	return func() (*token, error) {
+		form := url.Values{
+			"grant_type":    {"client_credentials"},
+			"client_id":     {clientID},
+			"client_secret": {clientSecret},
+		}
+		if len(scopes) > 0 {
+			form.Set("scope", strings.Join(scopes, " "))
+		}
+
+		req, err := http.NewRequest("POST", tokenURL, strings.NewReader(form.Encode()))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		return doTokenRequest(httpClient, req)
+	}
+}
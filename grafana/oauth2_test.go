@@ -0,0 +1,30 @@
+package grafana
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOAuth2ClientCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.PostForm.Get("grant_type") != "client_credentials" ||
+			r.PostForm.Get("client_id") != "my-client" ||
+			r.PostForm.Get("client_secret") != "my-secret" ||
+			r.PostForm.Get("scope") != "grafana:read grafana:write" {
+			t.Errorf("unexpected token request %v", r.PostForm)
+		}
+		w.Write([]byte(`{"access_token": "oauth2-token", "token_type": "Bearer", "expires_in": 300}`))
+	}))
+	defer server.Close()
+
+	fetch := oauth2ClientCredentials(http.DefaultClient, server.URL+"/token", "my-client", "my-secret", []string{"grafana:read", "grafana:write"})
+	tok, err := fetch()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if tok.accessToken != "oauth2-token" || tok.expiry.IsZero() {
+		t.Fatalf("unexpected token %#v", tok)
+	}
+}
@@ -0,0 +1,108 @@
+package grafana
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	gapi "github.com/nytm/go-grafana-api"
+)
+
+// permissionLevels maps the permission names used in configurations to the
+// values used by Grafana's permissions API.
+var permissionLevels = map[string]int64{
+	"View":  1,
+	"Edit":  2,
+	"Admin": 4,
+}
+
+// permissionsSchema is the schema of the permissions granted on a folder or
+// dashboard.
+func permissionsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"role": &schema.Schema{
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validateStringIn("Viewer", "Editor"),
+				},
+
+				"team_id": &schema.Schema{
+					Type:     schema.TypeInt,
+					Optional: true,
+				},
+
+				"user_id": &schema.Schema{
+					Type:     schema.TypeInt,
+					Optional: true,
+				},
+
+				"permission": &schema.Schema{
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validateStringIn("View", "Edit", "Admin"),
+				},
+			},
+		},
+	}
+}
+
+// makePermissionItems converts the permissions attribute to the items of
+// Grafana's permissions API.
+func makePermissionItems(d *schema.ResourceData) ([]gapi.PermissionItem, error) {
+	var items []gapi.PermissionItem
+	for _, p := range d.Get("permissions").(*schema.Set).List() {
+		p := p.(map[string]interface{})
+		item := gapi.PermissionItem{
+			Role:       p["role"].(string),
+			TeamId:     int64(p["team_id"].(int)),
+			UserId:     int64(p["user_id"].(int)),
+			Permission: permissionLevels[p["permission"].(string)],
+		}
+
+		grantees := 0
+		if item.Role != "" {
+			grantees++
+		}
+		if item.TeamId != 0 {
+			grantees++
+		}
+		if item.UserId != 0 {
+			grantees++
+		}
+		if grantees != 1 {
+			return nil, fmt.Errorf("Each permission must set exactly one of role, team_id or user_id")
+		}
+
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// flattenPermissionItems converts the items of Grafana's permissions API to
+// the permissions attribute, leaving out the ones inherited from a parent.
+func flattenPermissionItems(items []gapi.PermissionItem) []interface{} {
+	permissions := []interface{}{}
+	for _, item := range items {
+		if item.Inherited {
+			continue
+		}
+
+		permission := ""
+		for name, level := range permissionLevels {
+			if level == item.Permission {
+				permission = name
+			}
+		}
+
+		permissions = append(permissions, map[string]interface{}{
+			"role":       item.Role,
+			"team_id":    int(item.TeamId),
+			"user_id":    int(item.UserId),
+			"permission": permission,
+		})
+	}
+	return permissions
+}
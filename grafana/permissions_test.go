@@ -0,0 +1,70 @@
+package grafana
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	gapi "github.com/nytm/go-grafana-api"
+)
+
+func TestMakePermissionItems(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, ResourceFolderPermission().Schema, map[string]interface{}{
+		"folder_uid": "abc",
+		"permissions": []interface{}{
+			map[string]interface{}{"role": "Editor", "permission": "Edit"},
+			map[string]interface{}{"team_id": 3, "permission": "View"},
+			map[string]interface{}{"user_id": 7, "permission": "Admin"},
+		},
+	})
+
+	items, err := makePermissionItems(d)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	got := map[gapi.PermissionItem]bool{}
+	for _, item := range items {
+		got[item] = true
+	}
+	expected := map[gapi.PermissionItem]bool{
+		gapi.PermissionItem{Role: "Editor", Permission: 2}: true,
+		gapi.PermissionItem{TeamId: 3, Permission: 1}:      true,
+		gapi.PermissionItem{UserId: 7, Permission: 4}:      true,
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestMakePermissionItems_grantees(t *testing.T) {
+	for _, p := range []map[string]interface{}{
+		{"permission": "View"},
+		{"role": "Viewer", "user_id": 7, "permission": "View"},
+	} {
+		d := schema.TestResourceDataRaw(t, ResourceFolderPermission().Schema, map[string]interface{}{
+			"folder_uid":  "abc",
+			"permissions": []interface{}{p},
+		})
+		if _, err := makePermissionItems(d); err == nil {
+			t.Errorf("expected an error for %v", p)
+		}
+	}
+}
+
+func TestFlattenPermissionItems(t *testing.T) {
+	got := flattenPermissionItems([]gapi.PermissionItem{
+		{Role: "Viewer", Permission: 1},
+		{TeamId: 3, Permission: 2},
+		{UserId: 7, Permission: 4},
+		{Role: "Editor", Permission: 2, Inherited: true},
+	})
+	expected := []interface{}{
+		map[string]interface{}{"role": "Viewer", "team_id": 0, "user_id": 0, "permission": "View"},
+		map[string]interface{}{"role": "", "team_id": 3, "user_id": 0, "permission": "Edit"},
+		map[string]interface{}{"role": "", "team_id": 0, "user_id": 7, "permission": "Admin"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
@@ -1,6 +1,15 @@
 package grafana
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 
@@ -8,36 +17,573 @@ import (
 )
 
 func Provider() terraform.ResourceProvider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"url": &schema.Schema{
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("GRAFANA_URL", nil),
-				Description: "URL of the root of the target Grafana server.",
+				Description: "URL of the root of the target Grafana server. Required to manage Grafana instance resources.",
 			},
 			"auth": &schema.Schema{
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
 				DefaultFunc: schema.EnvDefaultFunc("GRAFANA_AUTH", nil),
-				Description: "Credentials for accessing the Grafana API.",
+				Description: "Credentials for accessing the Grafana API. Required when url is set. Set to \"sigv4\" to sign requests with AWS credentials instead.",
+			},
+			"sigv4": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "AWS SigV4 signing configuration, used when auth is \"sigv4\".",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"region": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The AWS region of the Amazon Managed Grafana workspace.",
+						},
+						"profile": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The AWS shared config profile to load credentials from.",
+						},
+						"role_arn": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The ARN of a role to assume before signing requests.",
+						},
+					},
+				},
+			},
+			"azure_ad": &schema.Schema{
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"sigv4"},
+				Description:   "Azure AD authentication for Azure Managed Grafana. When set, auth is not used.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tenant_id": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The Azure AD tenant of the service principal.",
+						},
+						"client_id": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The client ID of the service principal, or of the user-assigned managed identity.",
+						},
+						"client_secret": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "The client secret of the service principal.",
+						},
+						"use_managed_identity": &schema.Schema{
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Obtain tokens for the managed identity of the Azure resource the provider runs on.",
+						},
+						"authority_host": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "https://login.microsoftonline.com",
+							Description: "The Azure AD endpoint tokens are requested from.",
+						},
+					},
+				},
+			},
+			"oauth2": &schema.Schema{
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"sigv4", "azure_ad"},
+				Description:   "OAuth2 client credentials used to obtain bearer tokens for a proxy in front of Grafana. When set, auth is not used.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"token_url": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The URL of the OAuth2 token endpoint.",
+						},
+						"client_id": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The OAuth2 client ID.",
+						},
+						"client_secret": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							Description: "The OAuth2 client secret.",
+						},
+						"scopes": &schema.Schema{
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The scopes to request.",
+						},
+					},
+				},
+			},
+			"auth_file": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("GRAFANA_AUTH_FILE", nil),
+				ConflictsWith: []string{"sigv4", "azure_ad", "oauth2"},
+				Description:   "Path of a file holding an API token for the Grafana server. The file is read again periodically to pick up rotated tokens. When set, auth is not used.",
+			},
+			"auth_exec": &schema.Schema{
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"sigv4", "azure_ad", "oauth2", "auth_file"},
+				Description:   "A credential helper run to obtain API tokens for the Grafana server. When set, auth is not used.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"command": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The command to run. It must print a token, or a JSON object with token and expiry fields.",
+						},
+						"args": &schema.Schema{
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Arguments to pass to the command.",
+						},
+						"env": &schema.Schema{
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Environment variables to set for the command, in addition to those of Terraform.",
+						},
+					},
+				},
+			},
+			"timeout": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validateNonNegative,
+				Description:  "Timeout in seconds for requests made to the Grafana API. 0 means no timeout.",
+			},
+			"org_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GRAFANA_ORG_ID", 0),
+				Description: "The organization that all API calls are scoped to. Only applies to basic auth; API keys are always bound to their own organization.",
+			},
+			"proxy_url": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("GRAFANA_PROXY_URL", ""),
+				ValidateFunc: validateProxyURL,
+				Description:  "URL of an HTTP(S) or SOCKS5 proxy to send API requests through. Defaults to the standard proxy environment variables.",
+			},
+			"http_headers": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Sensitive:   true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Additional HTTP headers to send with every request to the Grafana API.",
+			},
+			"rate_limit": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validateNonNegative,
+				Description:  "Maximum number of requests per second sent to the Grafana API. 0 means no limit.",
+			},
+			"max_parallel_requests": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validateNonNegative,
+				Description:  "Maximum number of requests to the Grafana API that may be in flight at the same time. 0 means no limit.",
+			},
+			"send_request_id": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Send a unique X-Request-Id header with every request to the Grafana API.",
+			},
+			"cloud_api_key": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("GRAFANA_CLOUD_API_KEY", nil),
+				Description: "API key for the Grafana Cloud API, used to manage Grafana Cloud resources such as stacks.",
+			},
+			"cloud_api_url": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GRAFANA_CLOUD_API_URL", "https://grafana.com"),
+				Description: "URL of the Grafana Cloud API.",
+			},
+			"oncall_access_token": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("GRAFANA_ONCALL_ACCESS_TOKEN", nil),
+				Description: "Access token for the Grafana OnCall API, used to manage OnCall resources.",
+			},
+			"oncall_url": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GRAFANA_ONCALL_URL", "https://oncall-prod-us-central-0.grafana.net/oncall"),
+				Description: "URL of the Grafana OnCall API.",
+			},
+			"sm_access_token": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("GRAFANA_SM_ACCESS_TOKEN", nil),
+				Description: "Access token for the Synthetic Monitoring API, used to manage checks and probes.",
+			},
+			"sm_url": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GRAFANA_SM_URL", "https://synthetic-monitoring-api.grafana.net"),
+				Description: "URL of the Synthetic Monitoring API.",
 			},
 		},
 
+		DataSourcesMap: map[string]*schema.Resource{
+			"grafana_contact_point":      DataSourceContactPoint(),
+			"grafana_dashboard":          DataSourceDashboard(),
+			"grafana_dashboard_versions": DataSourceDashboardVersions(),
+			"grafana_dashboards":         DataSourceDashboards(),
+			"grafana_data_source":        DataSourceDataSource(),
+			"grafana_data_sources":       DataSourceDataSources(),
+			"grafana_folder":             DataSourceFolder(),
+			"grafana_folders":            DataSourceFolders(),
+			"grafana_library_panel":      DataSourceLibraryPanel(),
+			"grafana_organization":       DataSourceOrganization(),
+			"grafana_rule_group":         DataSourceRuleGroup(),
+		},
+
 		ResourcesMap: map[string]*schema.Resource{
-			"grafana_alert_notification": ResourceAlertNotification(),
-			"grafana_dashboard":          ResourceDashboard(),
-			"grafana_data_source":        ResourceDataSource(),
+			"grafana_alert_notification":       ResourceAlertNotification(),
+			"grafana_annotation":               ResourceAnnotation(),
+			"grafana_contact_point":            ResourceContactPoint(),
+			"grafana_correlation":              ResourceCorrelation(),
+			"grafana_dashboard":                ResourceDashboard(),
+			"grafana_dashboard_permission":     ResourceDashboardPermission(),
+			"grafana_dashboard_public":         ResourceDashboardPublic(),
+			"grafana_dashboard_star":           ResourceDashboardStar(),
+			"grafana_dashboards":               ResourceDashboards(),
+			"grafana_data_source":              ResourceDataSource(),
+			"grafana_data_source_caching":      ResourceDataSourceCaching(),
+			"grafana_data_source_config":       ResourceDataSourceConfig(),
+			"grafana_data_source_lbac_rules":   ResourceDataSourceLBACRules(),
+			"grafana_data_source_permission":   ResourceDataSourcePermission(),
+			"grafana_folder":                   ResourceFolder(),
+			"grafana_folder_permission":        ResourceFolderPermission(),
+			"grafana_library_panel":            ResourceLibraryPanel(),
+			"grafana_message_template":         ResourceMessageTemplate(),
+			"grafana_mute_timing":              ResourceMuteTiming(),
+			"grafana_ngalert_admin_config":     ResourceNgalertAdminConfig(),
+			"grafana_notification_policy":      ResourceNotificationPolicy(),
+			"grafana_organization":             ResourceOrganization(),
+			"grafana_organization_preferences": ResourceOrganizationPreferences(),
+			"grafana_organization_user":        ResourceOrganizationUser(),
+			"grafana_playlist":                 ResourcePlaylist(),
+			"grafana_rule_group":               ResourceRuleGroup(),
+			"grafana_short_url":                ResourceShortURL(),
+			"grafana_silence":                  ResourceSilence(),
+			"grafana_snapshot":                 ResourceSnapshot(),
 		},
+	}
 
-		ConfigureFunc: providerConfigure,
+	p.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
+		return providerConfigure(d, p.StopContext())
 	}
+
+	return p
 }
 
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
-	return gapi.New(
-		d.Get("auth").(string),
-		d.Get("url").(string),
-	)
+func providerConfigure(d *schema.ResourceData, stopCtx context.Context) (interface{}, error) {
+	baseTransport := cleanhttp.DefaultPooledTransport()
+	if v := d.Get("proxy_url").(string); v != "" {
+		proxyURL, err := url.Parse(v)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid proxy_url: %s", err)
+		}
+		baseTransport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	headers := map[string]string{}
+	for name, value := range d.Get("http_headers").(map[string]interface{}) {
+		headers[name] = value.(string)
+	}
+
+	// Custom headers are sensitive, so they're redacted from the logs along
+	// with the credentials.
+	redact := make([]string, 0, len(headers))
+	for name := range headers {
+		redact = append(redact, name)
+	}
+
+	// Token endpoints get a client of their own: their requests and
+	// responses carry secrets, so they must never be logged.
+	tokenClient := &http.Client{
+		Transport: &contextTransport{stopCtx, baseTransport},
+		Timeout:   time.Duration(d.Get("timeout").(int)) * time.Second,
+	}
+
+	transport, tokenAuth, err := authTransport(d, tokenClient, &loggingTransport{redact, baseTransport})
+	if err != nil {
+		return nil, err
+	}
+	if rateLimit, maxParallel := d.Get("rate_limit").(int), d.Get("max_parallel_requests").(int); rateLimit > 0 || maxParallel > 0 {
+		transport = newRateLimitTransport(rateLimit, maxParallel, transport)
+	}
+	transport = &contextTransport{stopCtx, transport}
+	transport = &userAgentTransport{userAgent(), d.Get("send_request_id").(bool), transport}
+	if len(headers) > 0 {
+		transport = &headerTransport{headers, transport}
+	}
+
+	grafanaURL, err := normalizeURL(d.Get("url").(string))
+	if err != nil {
+		return nil, fmt.Errorf("Invalid url: %s", err)
+	}
+
+	c := &client{
+		auth:      d.Get("auth").(string),
+		url:       grafanaURL,
+		timeout:   time.Duration(d.Get("timeout").(int)) * time.Second,
+		transport: transport,
+		orgID:     int64(d.Get("org_id").(int)),
+	}
+
+	if c.url != "" {
+		if c.auth == "" && !tokenAuth {
+			return nil, fmt.Errorf("One of auth, auth_file, auth_exec, azure_ad or oauth2 must be set when url is set")
+		}
+
+		c.gapi, err = c.newAPIClient(c.orgID)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := c.checkHealth(); err != nil {
+			return nil, err
+		}
+	}
+
+	// The instance's authentication, custom headers and organization aren't
+	// meant for the other Grafana services, so they share a transport of
+	// their own.
+	var serviceTransport http.RoundTripper = &loggingTransport{nil, baseTransport}
+	serviceTransport = &contextTransport{stopCtx, serviceTransport}
+	serviceTransport = &userAgentTransport{userAgent(), d.Get("send_request_id").(bool), serviceTransport}
+
+	if cloudAPIKey := d.Get("cloud_api_key").(string); cloudAPIKey != "" {
+		c.cloud, err = gapi.New(cloudAPIKey, d.Get("cloud_api_url").(string))
+		if err != nil {
+			return nil, fmt.Errorf("Invalid cloud_api_url: %s", err)
+		}
+
+		c.cloud.Transport = serviceTransport
+		c.cloud.Timeout = c.timeout
+	}
+
+	if token := d.Get("oncall_access_token").(string); token != "" {
+		c.oncall, err = gapi.New(token, d.Get("oncall_url").(string))
+		if err != nil {
+			return nil, fmt.Errorf("Invalid oncall_url: %s", err)
+		}
+
+		// The OnCall API expects the bare token rather than a bearer token
+		// in the Authorization header.
+		c.oncall.Transport = &headerTransport{
+			map[string]string{"Authorization": token},
+			serviceTransport,
+		}
+		c.oncall.Timeout = c.timeout
+	}
+
+	if token := d.Get("sm_access_token").(string); token != "" {
+		c.sm, err = gapi.New(token, d.Get("sm_url").(string))
+		if err != nil {
+			return nil, fmt.Errorf("Invalid sm_url: %s", err)
+		}
+
+		c.sm.Transport = serviceTransport
+		c.sm.Timeout = c.timeout
+	}
+
+	if c.gapi == nil && c.cloud == nil && c.oncall == nil && c.sm == nil {
+		return nil, fmt.Errorf("At least one of url, cloud_api_key, oncall_access_token or sm_access_token must be set")
+	}
+
+	return c, nil
+}
+
+// userAgent is the User-Agent sent with every request to the Grafana API.
+func userAgent() string {
+	return fmt.Sprintf("%s terraform-provider-grafana", terraform.UserAgentString())
+}
+
+// authTransport wraps transport with the request signing or token based
+// authentication configured for the Grafana instance, if any. The returned
+// bool is true when requests are authenticated with tokens obtained by the
+// provider itself, in which case the auth argument isn't used.
+func authTransport(d *schema.ResourceData, tokenClient *http.Client, transport http.RoundTripper) (http.RoundTripper, bool, error) {
+	if d.Get("auth").(string) == sigV4Auth {
+		if len(d.Get("sigv4").([]interface{})) == 0 {
+			return nil, false, fmt.Errorf("A sigv4 block must be given when auth is %q", sigV4Auth)
+		}
+
+		sigV4Transport, err := newSigV4Transport(
+			d.Get("sigv4.0.region").(string),
+			d.Get("sigv4.0.profile").(string),
+			d.Get("sigv4.0.role_arn").(string),
+			transport,
+		)
+		if err != nil {
+			return nil, false, err
+		}
+		return sigV4Transport, false, nil
+	}
+
+	if len(d.Get("azure_ad").([]interface{})) > 0 {
+		clientID := d.Get("azure_ad.0.client_id").(string)
+
+		var fetch func() (*token, error)
+		if d.Get("azure_ad.0.use_managed_identity").(bool) {
+			fetch = azureManagedIdentity(tokenClient, clientID)
+		} else {
+			tenantID := d.Get("azure_ad.0.tenant_id").(string)
+			clientSecret := d.Get("azure_ad.0.client_secret").(string)
+			if tenantID == "" || clientID == "" || clientSecret == "" {
+				return nil, false, fmt.Errorf("azure_ad requires tenant_id, client_id and client_secret unless use_managed_identity is set")
+			}
+			fetch = azureADClientCredentials(tokenClient, d.Get("azure_ad.0.authority_host").(string), tenantID, clientID, clientSecret)
+		}
+
+		return &tokenTransport{fetch: fetch, transport: transport}, true, nil
+	}
+
+	if len(d.Get("oauth2").([]interface{})) > 0 {
+		var scopes []string
+		for _, scope := range d.Get("oauth2.0.scopes").([]interface{}) {
+			scopes = append(scopes, scope.(string))
+		}
+
+		fetch := oauth2ClientCredentials(
+			tokenClient,
+			d.Get("oauth2.0.token_url").(string),
+			d.Get("oauth2.0.client_id").(string),
+			d.Get("oauth2.0.client_secret").(string),
+			scopes,
+		)
+
+		return &tokenTransport{fetch: fetch, transport: transport}, true, nil
+	}
+
+	if path := d.Get("auth_file").(string); path != "" {
+		return &tokenTransport{fetch: tokenFromFile(path), transport: transport}, true, nil
+	}
+
+	if len(d.Get("auth_exec").([]interface{})) > 0 {
+		var args []string
+		for _, arg := range d.Get("auth_exec.0.args").([]interface{}) {
+			args = append(args, arg.(string))
+		}
+		env := map[string]string{}
+		for name, value := range d.Get("auth_exec.0.env").(map[string]interface{}) {
+			env[name] = value.(string)
+		}
+
+		fetch := tokenFromCommand(d.Get("auth_exec.0.command").(string), args, env)
+		return &tokenTransport{fetch: fetch, transport: transport}, true, nil
+	}
+
+	return transport, false, nil
+}
+
+// normalizeURL cleans up the URL of a Grafana server, which may be hosted
+// under a subpath such as https://example.com/grafana/. Surrounding
+// whitespace, duplicate and trailing slashes, queries and fragments are
+// removed so API paths can be appended to the result as-is.
+func normalizeURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", nil
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("%q must use the http or https scheme", raw)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("%q must include a host", raw)
+	}
+
+	u.Path = strings.TrimSuffix(path.Clean("/"+u.Path), "/")
+	u.RawPath = ""
+	u.RawQuery = ""
+	u.Fragment = ""
+
+	return u.String(), nil
+}
+
+func validateProxyURL(v interface{}, k string) ([]string, []error) {
+	value := v.(string)
+	if value == "" {
+		return nil, nil
+	}
+
+	u, err := url.Parse(value)
+	if err != nil {
+		return nil, []error{fmt.Errorf("%q must be a valid URL: %s", k, err)}
+	}
+
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, []error{fmt.Errorf("%q must use one of the http, https or socks5 schemes, got %q", k, u.Scheme)}
+	}
+
+	if u.Host == "" {
+		return nil, []error{fmt.Errorf("%q must include a host", k)}
+	}
+
+	return nil, nil
+}
+
+func validateNonNegative(v interface{}, k string) ([]string, []error) {
+	if v.(int) < 0 {
+		return nil, []error{fmt.Errorf("%q must not be negative", k)}
+	}
+	return nil, nil
+}
+
+func validateStringIn(values ...string) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) ([]string, []error) {
+		for _, value := range values {
+			if v.(string) == value {
+				return nil, nil
+			}
+		}
+		return nil, []error{fmt.Errorf("%q must be one of %q, got %q", k, values, v)}
+	}
+}
+
+// validateUID checks that a UID is one Grafana accepts, as it does for
+// dashboards, data sources and other objects.
+func validateUID(v interface{}, k string) ([]string, []error) {
+	if !dashboardUIDPattern.MatchString(v.(string)) {
+		return nil, []error{fmt.Errorf("%q must be at most 40 letters, digits, dashes and underscores, got %q", k, v)}
+	}
+	return nil, nil
 }
@@ -1,9 +1,13 @@
 package grafana
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -44,6 +48,208 @@ func TestProvider_impl(t *testing.T) {
 	var _ terraform.ResourceProvider = Provider()
 }
 
+func TestProviderConfigure_cloudOnly(t *testing.T) {
+	raw, err := config.NewRawConfig(map[string]interface{}{
+		"url":           "",
+		"cloud_api_key": "cloud-key",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	p := Provider().(*schema.Provider)
+	if err := p.Configure(terraform.NewResourceConfig(raw)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	c := p.Meta().(*client)
+	if _, err := c.cloudClient(); err != nil {
+		t.Fatalf("expected a Cloud API client, got %s", err)
+	}
+	if _, err := c.forOrg(0); err == nil {
+		t.Fatalf("expected instance resources to require url and auth")
+	}
+}
+
+func TestProviderConfigure_onCall(t *testing.T) {
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	raw, err := config.NewRawConfig(map[string]interface{}{
+		"url":                 "",
+		"oncall_access_token": "oncall-token",
+		"oncall_url":          server.URL,
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	p := Provider().(*schema.Provider)
+	if err := p.Configure(terraform.NewResourceConfig(raw)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	onCall, err := p.Meta().(*client).onCallClient()
+	if err != nil {
+		t.Fatalf("expected an OnCall API client, got %s", err)
+	}
+	if _, err := onCall.Health(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if gotAuth != "oncall-token" {
+		t.Fatalf("expected the bare token to be sent, got %q", gotAuth)
+	}
+}
+
+func TestProviderConfigure_syntheticMonitoring(t *testing.T) {
+	raw, err := config.NewRawConfig(map[string]interface{}{
+		"url":             "",
+		"sm_access_token": "sm-token",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	p := Provider().(*schema.Provider)
+	if err := p.Configure(terraform.NewResourceConfig(raw)); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if _, err := p.Meta().(*client).smClient(); err != nil {
+		t.Fatalf("expected a Synthetic Monitoring API client, got %s", err)
+	}
+}
+
+func TestProviderConfigure_nothingToManage(t *testing.T) {
+	raw, err := config.NewRawConfig(map[string]interface{}{
+		"url":                 "",
+		"cloud_api_key":       "",
+		"oncall_access_token": "",
+		"sm_access_token":     "",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	p := Provider().(*schema.Provider)
+	if err := p.Configure(terraform.NewResourceConfig(raw)); err == nil {
+		t.Fatalf("expected an error when neither url nor cloud_api_key is set")
+	}
+}
+
+func TestNormalizeURL(t *testing.T) {
+	cases := map[string]string{
+		"":                               "",
+		"http://grafana.local:3000":      "http://grafana.local:3000",
+		"http://grafana.local:3000/":     "http://grafana.local:3000",
+		" https://example.com/grafana ":  "https://example.com/grafana",
+		"https://example.com/grafana/":   "https://example.com/grafana",
+		"https://example.com//grafana//": "https://example.com/grafana",
+		"https://example.com/a/b/?x=1#y": "https://example.com/a/b",
+	}
+
+	for raw, expected := range cases {
+		got, err := normalizeURL(raw)
+		if err != nil {
+			t.Errorf("expected %q to be valid, got %s", raw, err)
+			continue
+		}
+		if got != expected {
+			t.Errorf("expected %q to normalize to %q, got %q", raw, expected, got)
+		}
+	}
+
+	for _, raw := range []string{"grafana.local:3000", "ftp://grafana.local", "http://", "http://%zz"} {
+		if _, err := normalizeURL(raw); err == nil {
+			t.Errorf("expected %q to be invalid", raw)
+		}
+	}
+}
+
+func TestProviderConfigure_subpath(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/grafana/api/health", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"commit": "abc", "database": "ok", "version": "5.1.3"}`))
+	})
+	mux.HandleFunc("/grafana/api/datasources/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 1, "name": "influxdb"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	for _, grafanaURL := range []string{server.URL + "/grafana", server.URL + "/grafana/", server.URL + "/grafana//"} {
+		raw, err := config.NewRawConfig(map[string]interface{}{
+			"url":  grafanaURL,
+			"auth": "admin:admin",
+		})
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		p := Provider().(*schema.Provider)
+		if err := p.Configure(terraform.NewResourceConfig(raw)); err != nil {
+			t.Fatalf("expected %s to be configured, got %s", grafanaURL, err)
+		}
+
+		apiClient := p.Meta().(*client).gapi
+		dataSource, err := apiClient.DataSource(1)
+		if err != nil {
+			t.Fatalf("expected %s to be reachable, got %s", grafanaURL, err)
+		}
+		if dataSource.Name != "influxdb" {
+			t.Fatalf("unexpected data source %#v", dataSource)
+		}
+	}
+}
+
+func TestValidateProxyURL(t *testing.T) {
+	cases := map[string]bool{
+		"":                        true,
+		"http://proxy.local:3128": true,
+		"https://proxy.local":     true,
+		"socks5://127.0.0.1:1080": true,
+		"ftp://proxy.local":       false,
+		"proxy.local:3128":        false,
+		"http://":                 false,
+		"http://%zz":              false,
+	}
+
+	for value, valid := range cases {
+		_, errs := validateProxyURL(value, "proxy_url")
+		if valid && len(errs) > 0 {
+			t.Errorf("expected %q to be valid, got %v", value, errs)
+		}
+		if !valid && len(errs) == 0 {
+			t.Errorf("expected %q to be invalid", value)
+		}
+	}
+}
+
+func TestValidateStringIn(t *testing.T) {
+	validate := validateStringIn("", "light", "dark")
+	cases := map[string]bool{
+		"":      true,
+		"light": true,
+		"dark":  true,
+		"Dark":  false,
+		"blue":  false,
+	}
+
+	for value, valid := range cases {
+		_, errs := validate(value, "theme")
+		if valid && len(errs) > 0 {
+			t.Errorf("expected %q to be valid, got %v", value, errs)
+		}
+		if !valid && len(errs) == 0 {
+			t.Errorf("expected %q to be invalid", value)
+		}
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	if v := os.Getenv("GRAFANA_URL"); v == "" {
 		t.Fatal("GRAFANA_URL must be set for acceptance tests")
@@ -52,3 +258,28 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("GRAFANA_AUTH must be set for acceptance tests")
 	}
 }
+
+// testAccPreCheckEnterprise skips acceptance tests of Grafana Enterprise
+// features unless GRAFANA_ENTERPRISE is set.
+func testAccPreCheckEnterprise(t *testing.T) {
+	testAccPreCheck(t)
+	if v := os.Getenv("GRAFANA_ENTERPRISE"); v == "" {
+		t.Skip("GRAFANA_ENTERPRISE must be set to test Grafana Enterprise features")
+	}
+}
+
+// newTestClient returns a provider client for the default organization of
+// a test server, as the provider configures it.
+func newTestClient(t *testing.T, server *httptest.Server) *client {
+	c := &client{
+		auth:      "admin:admin",
+		url:       server.URL,
+		transport: cleanhttp.DefaultPooledTransport(),
+	}
+	var err error
+	c.gapi, err = c.newAPIClient(0)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	return c
+}
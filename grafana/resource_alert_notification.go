@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	gapi "github.com/nytm/go-grafana-api"
@@ -15,8 +16,13 @@ func ResourceAlertNotification() *schema.Resource {
 		Update: UpdateAlertNotification,
 		Delete: DeleteAlertNotification,
 		Read:   ReadAlertNotification,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
 
 		Schema: map[string]*schema.Schema{
+			"org_id": orgIDSchema(),
+
 			"id": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
@@ -38,17 +44,46 @@ func ResourceAlertNotification() *schema.Resource {
 				Default:  false,
 			},
 
+			"send_reminder": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"frequency": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validateAlertNotificationFrequency,
+				DiffSuppressFunc: suppressAlertNotificationFrequencyDiff,
+			},
+
+			"disable_resolve_message": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			"settings": {
 				Type:      schema.TypeMap,
 				Optional:  true,
 				Sensitive: true,
 			},
+
+			"secure_settings": &schema.Schema{
+				Type:      schema.TypeMap,
+				Optional:  true,
+				Sensitive: true,
+				Elem:      &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
 
 func CreateAlertNotification(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*gapi.Client)
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
 
 	alertNotification, err := makeAlertNotification(d)
 	if err != nil {
@@ -57,7 +92,7 @@ func CreateAlertNotification(d *schema.ResourceData, meta interface{}) error {
 
 	id, err := client.NewAlertNotification(alertNotification)
 	if err != nil {
-		return err
+		return accessError(err, "creating alert notification")
 	}
 
 	d.SetId(strconv.FormatInt(id, 10))
@@ -66,18 +101,29 @@ func CreateAlertNotification(d *schema.ResourceData, meta interface{}) error {
 }
 
 func UpdateAlertNotification(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*gapi.Client)
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
 
 	alertNotification, err := makeAlertNotification(d)
 	if err != nil {
 		return err
 	}
 
-	return client.UpdateAlertNotification(alertNotification)
+	err = client.UpdateAlertNotification(alertNotification)
+	if err != nil {
+		return accessError(err, fmt.Sprintf("updating alert notification %s", d.Id()))
+	}
+
+	return ReadAlertNotification(d, meta)
 }
 
 func ReadAlertNotification(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*gapi.Client)
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
 
 	idStr := d.Id()
 	id, err := strconv.ParseInt(idStr, 10, 64)
@@ -87,25 +133,48 @@ func ReadAlertNotification(d *schema.ResourceData, meta interface{}) error {
 
 	alertNotification, err := client.AlertNotification(id)
 	if err != nil {
-		if err.Error() == "404 Not Found" {
-			log.Printf("[WARN] removing datasource %s from state because it no longer exists in grafana", d.Get("name").(string))
+		if isNotFound(err) {
+			log.Printf("[WARN] removing alert notification %s from state because it no longer exists in grafana", d.Get("name").(string))
 			d.SetId("")
 			return nil
 		}
-		return err
+		return accessError(err, fmt.Sprintf("reading alert notification %s", idStr))
 	}
 
 	d.Set("id", alertNotification.Id)
 	d.Set("is_default", alertNotification.IsDefault)
 	d.Set("name", alertNotification.Name)
 	d.Set("type", alertNotification.Type)
+	d.Set("send_reminder", alertNotification.SendReminder)
+	d.Set("frequency", alertNotification.Frequency)
+	d.Set("disable_resolve_message", alertNotification.DisableResolveMessage)
 	d.Set("settings", alertNotification.Settings)
+	readAlertNotificationSecureSettings(d, alertNotification)
 
 	return nil
 }
 
+// readAlertNotificationSecureSettings keeps the secure settings as they were
+// last set, since Grafana never returns them, and clears the ones Grafana no
+// longer has, so that they show up as changes to set them again.
+func readAlertNotificationSecureSettings(d *schema.ResourceData, alertNotification *gapi.AlertNotification) {
+	if alertNotification.SecureFields == nil {
+		return
+	}
+	secureSettings := map[string]interface{}{}
+	for key, value := range d.Get("secure_settings").(map[string]interface{}) {
+		if alertNotification.SecureFields[key] {
+			secureSettings[key] = value
+		}
+	}
+	d.Set("secure_settings", secureSettings)
+}
+
 func DeleteAlertNotification(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*gapi.Client)
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
 
 	idStr := d.Id()
 	id, err := strconv.ParseInt(idStr, 10, 64)
@@ -113,7 +182,12 @@ func DeleteAlertNotification(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Invalid id: %#v", idStr)
 	}
 
-	return client.DeleteAlertNotification(id)
+	err = client.DeleteAlertNotification(id)
+	if err != nil && !isNotFound(err) {
+		return accessError(err, fmt.Sprintf("deleting alert notification %s", idStr))
+	}
+
+	return nil
 }
 
 func makeAlertNotification(d *schema.ResourceData) (*gapi.AlertNotification, error) {
@@ -124,11 +198,45 @@ func makeAlertNotification(d *schema.ResourceData) (*gapi.AlertNotification, err
 		id, err = strconv.ParseInt(idStr, 10, 64)
 	}
 
+	if err == nil && d.Get("send_reminder").(bool) && d.Get("frequency").(string) == "" {
+		err = fmt.Errorf("frequency must be set when send_reminder is true")
+	}
+
+	secureSettings := map[string]string{}
+	for key, value := range d.Get("secure_settings").(map[string]interface{}) {
+		secureSettings[key] = value.(string)
+	}
+
 	return &gapi.AlertNotification{
-		Id:        id,
-		Name:      d.Get("name").(string),
-		Type:      d.Get("type").(string),
-		IsDefault: d.Get("is_default").(bool),
-		Settings:  d.Get("settings").(interface{}),
+		Id:                    id,
+		Name:                  d.Get("name").(string),
+		Type:                  d.Get("type").(string),
+		IsDefault:             d.Get("is_default").(bool),
+		SendReminder:          d.Get("send_reminder").(bool),
+		Frequency:             d.Get("frequency").(string),
+		DisableResolveMessage: d.Get("disable_resolve_message").(bool),
+		Settings:              d.Get("settings").(interface{}),
+		SecureSettings:        secureSettings,
 	}, err
 }
+
+func validateAlertNotificationFrequency(v interface{}, k string) ([]string, []error) {
+	if _, err := time.ParseDuration(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%q must be a duration, e.g. 15m: %s", k, err)}
+	}
+	return nil, nil
+}
+
+// suppressAlertNotificationFrequencyDiff compares frequencies as durations,
+// since Grafana returns them in its own format, e.g. 1h for 60m.
+func suppressAlertNotificationFrequencyDiff(k, old, new string, d *schema.ResourceData) bool {
+	o, err := time.ParseDuration(old)
+	if err != nil {
+		return false
+	}
+	n, err := time.ParseDuration(new)
+	if err != nil {
+		return false
+	}
+	return o == n
+}
@@ -2,6 +2,7 @@ package grafana
 
 import (
 	"fmt"
+	"reflect"
 	"regexp"
 	"strconv"
 	"testing"
@@ -9,6 +10,7 @@ import (
 	gapi "github.com/nytm/go-grafana-api"
 
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 )
 
@@ -35,6 +37,24 @@ func TestAccAlertNotification_basic(t *testing.T) {
 					),
 				),
 			},
+			resource.TestStep{
+				Config: testAccAlertNotificationConfig_reminder,
+				Check: resource.ComposeTestCheckFunc(
+					testAccAlertNotificationCheckExists("grafana_alert_notification.test", &alertNotification),
+					resource.TestCheckResourceAttr(
+						"grafana_alert_notification.test", "send_reminder", "true",
+					),
+					resource.TestCheckResourceAttr(
+						"grafana_alert_notification.test", "secure_settings.url", "https://hooks.slack.test/services/T0/B0/X",
+					),
+				),
+			},
+			resource.TestStep{
+				ResourceName:            "grafana_alert_notification.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secure_settings"},
+			},
 		},
 	})
 }
@@ -55,7 +75,7 @@ func testAccAlertNotificationCheckExists(rn string, a *gapi.AlertNotification) r
 			return fmt.Errorf("resource id is malformed")
 		}
 
-		client := testAccProvider.Meta().(*gapi.Client)
+		client := testAccProvider.Meta().(*client).gapi
 		gotAlertNotification, err := client.AlertNotification(id)
 		if err != nil {
 			return fmt.Errorf("error getting data source: %s", err)
@@ -69,7 +89,7 @@ func testAccAlertNotificationCheckExists(rn string, a *gapi.AlertNotification) r
 
 func testAccAlertNotificationCheckDestroy(a *gapi.AlertNotification) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*gapi.Client)
+		client := testAccProvider.Meta().(*client).gapi
 		alert, err := client.AlertNotification(a.Id)
 		if err == nil && alert != nil {
 			return fmt.Errorf("alert-notification still exists")
@@ -88,3 +108,55 @@ resource "grafana_alert_notification" "test" {
 		}
 }
 `
+
+const testAccAlertNotificationConfig_reminder = `
+resource "grafana_alert_notification" "test" {
+    type          = "slack"
+    name          = "terraform-acc-test"
+    send_reminder = true
+    frequency     = "1h"
+
+    settings {
+        "recipient" = "#alerts"
+    }
+
+    secure_settings {
+        "url" = "https://hooks.slack.test/services/T0/B0/X"
+    }
+}
+`
+
+func TestReadAlertNotificationSecureSettings(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, ResourceAlertNotification().Schema, map[string]interface{}{
+		"name": "pager",
+		"type": "pagerduty",
+		"secure_settings": map[string]interface{}{
+			"integrationKey": "secret",
+			"reset":          "in the web UI",
+		},
+	})
+	readAlertNotificationSecureSettings(d, &gapi.AlertNotification{
+		SecureFields: map[string]bool{"integrationKey": true},
+	})
+
+	expected := map[string]interface{}{"integrationKey": "secret"}
+	if secureSettings := d.Get("secure_settings").(map[string]interface{}); !reflect.DeepEqual(secureSettings, expected) {
+		t.Fatalf("expected secure settings %v, got %v", expected, secureSettings)
+	}
+}
+
+func TestSuppressAlertNotificationFrequencyDiff(t *testing.T) {
+	for _, tc := range []struct {
+		old, new string
+		suppress bool
+	}{
+		{"1h", "60m", true},
+		{"15m", "15m0s", true},
+		{"15m", "30m", false},
+		{"", "15m", false},
+	} {
+		if suppress := suppressAlertNotificationFrequencyDiff("frequency", tc.old, tc.new, nil); suppress != tc.suppress {
+			t.Errorf("%s -> %s: expected suppress to be %t", tc.old, tc.new, tc.suppress)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package grafana
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	gapi "github.com/nytm/go-grafana-api"
+)
+
+func ResourceAnnotation() *schema.Resource {
+	return &schema.Resource{
+		Create: CreateAnnotation,
+		Read:   ReadAnnotation,
+		Update: UpdateAnnotation,
+		Delete: DeleteAnnotation,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDSchema(),
+
+			"text": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"tags": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"time": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateAnnotationTime,
+				DiffSuppressFunc: suppressEqualAnnotationTimes,
+			},
+
+			"time_end": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validateAnnotationTime,
+				DiffSuppressFunc: suppressEqualAnnotationTimes,
+			},
+
+			"dashboard_uid": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"panel_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func CreateAnnotation(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	annotation, err := makeAnnotation(d)
+	if err != nil {
+		return err
+	}
+	if annotation.PanelId != 0 && annotation.DashboardUID == "" {
+		return fmt.Errorf("panel_id requires dashboard_uid to be set")
+	}
+
+	id, err := client.NewAnnotation(annotation)
+	if err != nil {
+		return accessError(err, "creating annotation")
+	}
+
+	d.SetId(strconv.FormatInt(id, 10))
+
+	return ReadAnnotation(d, meta)
+}
+
+func ReadAnnotation(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Invalid id: %#v", d.Id())
+	}
+
+	annotation, err := client.Annotation(id)
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] removing annotation %s from state because it no longer exists in grafana", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return accessError(err, fmt.Sprintf("reading annotation %s", d.Id()))
+	}
+
+	d.Set("text", annotation.Text)
+	d.Set("tags", annotation.Tags)
+	d.Set("time", formatAnnotationTime(annotation.Time))
+	if annotation.TimeEnd != 0 && annotation.TimeEnd != annotation.Time {
+		d.Set("time_end", formatAnnotationTime(annotation.TimeEnd))
+	} else {
+		d.Set("time_end", "")
+	}
+	d.Set("dashboard_uid", annotation.DashboardUID)
+	d.Set("panel_id", annotation.PanelId)
+
+	return nil
+}
+
+func UpdateAnnotation(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Invalid id: %#v", d.Id())
+	}
+
+	annotation, err := makeAnnotation(d)
+	if err != nil {
+		return err
+	}
+
+	if err := client.UpdateAnnotation(id, annotation); err != nil {
+		return accessError(err, fmt.Sprintf("updating annotation %s", d.Id()))
+	}
+
+	return ReadAnnotation(d, meta)
+}
+
+func DeleteAnnotation(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Invalid id: %#v", d.Id())
+	}
+
+	err = client.DeleteAnnotation(id)
+	if err != nil && !isNotFound(err) {
+		return accessError(err, fmt.Sprintf("deleting annotation %s", d.Id()))
+	}
+
+	return nil
+}
+
+func makeAnnotation(d *schema.ResourceData) (gapi.Annotation, error) {
+	annotation := gapi.Annotation{
+		DashboardUID: d.Get("dashboard_uid").(string),
+		PanelId:      int64(d.Get("panel_id").(int)),
+		Text:         d.Get("text").(string),
+		Tags:         []string{},
+	}
+	for _, tag := range d.Get("tags").(*schema.Set).List() {
+		annotation.Tags = append(annotation.Tags, tag.(string))
+	}
+
+	t, err := time.Parse(time.RFC3339, d.Get("time").(string))
+	if err != nil {
+		return annotation, err
+	}
+	annotation.Time = annotationTime(t)
+
+	if timeEnd := d.Get("time_end").(string); timeEnd != "" {
+		t, err := time.Parse(time.RFC3339, timeEnd)
+		if err != nil {
+			return annotation, err
+		}
+		annotation.TimeEnd = annotationTime(t)
+	}
+
+	return annotation, nil
+}
+
+// annotationTime converts t to the milliseconds since the epoch used by the
+// annotations API.
+func annotationTime(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+func formatAnnotationTime(ms int64) string {
+	return time.Unix(0, ms*int64(time.Millisecond)).UTC().Format(time.RFC3339Nano)
+}
+
+func validateAnnotationTime(v interface{}, k string) ([]string, []error) {
+	if _, err := time.Parse(time.RFC3339, v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%s must be an RFC 3339 timestamp, such as 2018-01-02T15:04:05Z: %s", k, err)}
+	}
+	return nil, nil
+}
+
+// suppressEqualAnnotationTimes ignores differences in how the same time is
+// written, such as its time zone, since Grafana returns times in UTC.
+func suppressEqualAnnotationTimes(k, old, new string, d *schema.ResourceData) bool {
+	oldTime, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+	newTime, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+	return oldTime.Equal(newTime)
+}
@@ -0,0 +1,167 @@
+package grafana
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"testing"
+
+	gapi "github.com/nytm/go-grafana-api"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAnnotation_basic(t *testing.T) {
+	var annotation gapi.Annotation
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccAnnotationCheckDestroy(&annotation),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccAnnotationConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccAnnotationCheckExists("grafana_annotation.test", &annotation),
+					resource.TestCheckResourceAttr("grafana_annotation.test", "text", "Deployed v1.2.3"),
+					resource.TestCheckResourceAttr("grafana_annotation.test", "tags.#", "2"),
+					resource.TestCheckResourceAttrPair(
+						"grafana_annotation.test", "dashboard_uid",
+						"grafana_dashboard.test", "uid",
+					),
+				),
+			},
+			resource.TestStep{
+				Config: testAccAnnotationConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccAnnotationCheckExists("grafana_annotation.test", &annotation),
+					resource.TestCheckResourceAttr("grafana_annotation.test", "text", "Maintenance"),
+					resource.TestCheckResourceAttr("grafana_annotation.test", "time_end", "2018-01-02T16:04:05Z"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "grafana_annotation.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestMakeAnnotation(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, ResourceAnnotation().Schema, map[string]interface{}{
+		"text":          "Maintenance",
+		"tags":          []interface{}{"ops"},
+		"time":          "2018-01-02T15:04:05+01:00",
+		"time_end":      "2018-01-02T15:34:05.5+01:00",
+		"dashboard_uid": "abc",
+		"panel_id":      2,
+	})
+
+	annotation, err := makeAnnotation(d)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	expected := gapi.Annotation{
+		DashboardUID: "abc",
+		PanelId:      2,
+		Time:         1514901845000,
+		TimeEnd:      1514903645500,
+		Text:         "Maintenance",
+		Tags:         []string{"ops"},
+	}
+	if !reflect.DeepEqual(annotation, expected) {
+		t.Fatalf("expected %v, got %v", expected, annotation)
+	}
+
+	if got := formatAnnotationTime(annotation.Time); got != "2018-01-02T14:04:05Z" {
+		t.Fatalf("unexpected formatted time %s", got)
+	}
+	if got := formatAnnotationTime(annotation.TimeEnd); got != "2018-01-02T14:34:05.5Z" {
+		t.Fatalf("unexpected formatted time %s", got)
+	}
+}
+
+func TestSuppressEqualAnnotationTimes(t *testing.T) {
+	if !suppressEqualAnnotationTimes("time", "2018-01-02T14:04:05Z", "2018-01-02T15:04:05+01:00", nil) {
+		t.Errorf("expected times in different time zones to be equal")
+	}
+	if suppressEqualAnnotationTimes("time", "2018-01-02T14:04:05Z", "2018-01-02T15:04:05Z", nil) {
+		t.Errorf("expected different times to differ")
+	}
+	if suppressEqualAnnotationTimes("time_end", "", "2018-01-02T15:04:05Z", nil) {
+		t.Errorf("expected a time being set to be a difference")
+	}
+}
+
+func testAccAnnotationCheckExists(rn string, annotation *gapi.Annotation) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		id, err := strconv.ParseInt(rs.Primary.ID, 10, 64)
+		if err != nil {
+			return fmt.Errorf("resource id is malformed")
+		}
+
+		client := testAccProvider.Meta().(*client).gapi
+		gotAnnotation, err := client.Annotation(id)
+		if err != nil {
+			return fmt.Errorf("error getting annotation: %s", err)
+		}
+
+		*annotation = *gotAnnotation
+
+		return nil
+	}
+}
+
+func testAccAnnotationCheckDestroy(annotation *gapi.Annotation) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*client).gapi
+		_, err := client.Annotation(annotation.Id)
+		if err == nil {
+			return fmt.Errorf("annotation still exists")
+		}
+		return nil
+	}
+}
+
+const testAccAnnotationConfig_basic = `
+resource "grafana_dashboard" "test" {
+    config_json = <<EOT
+{
+    "title": "Terraform Acceptance Test Annotation"
+}
+EOT
+}
+
+resource "grafana_annotation" "test" {
+    dashboard_uid = "${grafana_dashboard.test.uid}"
+    text          = "Deployed v1.2.3"
+    tags          = ["deploy", "tf-acc-test"]
+    time          = "2018-01-02T15:04:05Z"
+}
+`
+
+const testAccAnnotationConfig_update = `
+resource "grafana_dashboard" "test" {
+    config_json = <<EOT
+{
+    "title": "Terraform Acceptance Test Annotation"
+}
+EOT
+}
+
+resource "grafana_annotation" "test" {
+    dashboard_uid = "${grafana_dashboard.test.uid}"
+    text          = "Maintenance"
+    tags          = ["maintenance", "tf-acc-test"]
+    time          = "2018-01-02T15:04:05Z"
+    time_end      = "2018-01-02T16:04:05Z"
+}
+`
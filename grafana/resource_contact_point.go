@@ -0,0 +1,185 @@
+package grafana
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform/helper/schema"
+	gapi "github.com/nytm/go-grafana-api"
+)
+
+func ResourceContactPoint() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"org_id":             orgIDSchema(),
+		"disable_provenance": disableProvenanceSchema(),
+
+		"name": &schema.Schema{
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	}
+	for _, notifier := range contactPointNotifiers {
+		s[notifier.attribute] = notifierSchema(notifier.fields)
+	}
+
+	return &schema.Resource{
+		Create: CreateContactPoint,
+		Read:   ReadContactPoint,
+		Update: UpdateContactPoint,
+		Delete: DeleteContactPoint,
+		Importer: &schema.ResourceImporter{
+			State: importOrgResource,
+		},
+
+		Schema: s,
+	}
+}
+
+func CreateContactPoint(d *schema.ResourceData, meta interface{}) error {
+	if err := meta.(*client).requireVersion("grafana_contact_point", "9.1.0"); err != nil {
+		return err
+	}
+
+	client, err := alertingClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+	existing, err := client.ContactPointsByName(name)
+	if err != nil {
+		return accessError(err, fmt.Sprintf("reading contact point %s", name))
+	}
+	if len(existing) > 0 {
+		return fmt.Errorf("Contact point %q already exists: import it to manage it", name)
+	}
+
+	setOrgResourceID(d, name)
+
+	if err := saveContactPoints(d, client, nil); err != nil {
+		return err
+	}
+
+	return ReadContactPoint(d, meta)
+}
+
+func UpdateContactPoint(d *schema.ResourceData, meta interface{}) error {
+	client, err := alertingClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	existing, err := client.ContactPointsByName(orgResourceID(d))
+	if err != nil {
+		return accessError(err, fmt.Sprintf("reading contact point %s", orgResourceID(d)))
+	}
+
+	setOrgResourceID(d, d.Get("name").(string))
+
+	if err := saveContactPoints(d, client, existing); err != nil {
+		return err
+	}
+
+	return ReadContactPoint(d, meta)
+}
+
+func ReadContactPoint(d *schema.ResourceData, meta interface{}) error {
+	client, err := alertingClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	points, err := client.ContactPointsByName(orgResourceID(d))
+	if err != nil {
+		return accessError(err, fmt.Sprintf("reading contact point %s", orgResourceID(d)))
+	}
+	if len(points) == 0 {
+		log.Printf("[WARN] removing contact point %s from state because it no longer exists in grafana", orgResourceID(d))
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", orgResourceID(d))
+	d.Set("disable_provenance", points[0].Provenance == "")
+	return readContactPoints(d, points)
+}
+
+func DeleteContactPoint(d *schema.ResourceData, meta interface{}) error {
+	client, err := alertingClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	points, err := client.ContactPointsByName(orgResourceID(d))
+	if err != nil {
+		return accessError(err, fmt.Sprintf("reading contact point %s", orgResourceID(d)))
+	}
+
+	var result *multierror.Error
+	for _, point := range points {
+		if err := client.DeleteContactPoint(point.Uid); err != nil && !isNotFound(err) {
+			result = multierror.Append(result, accessError(err, fmt.Sprintf("deleting %s integration %s of contact point %s", point.Type, point.Uid, orgResourceID(d))))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+// saveContactPoints updates the existing integrations of the contact point
+// that are still configured, creates the new ones and deletes the others.
+// The UIDs of the integrations are recorded in their blocks, so that they
+// are read back in the same order.
+func saveContactPoints(d *schema.ResourceData, client *gapi.Client, existing []gapi.ContactPoint) error {
+	points := makeContactPoints(d)
+	if len(points) == 0 {
+		return fmt.Errorf("Contact point %s must have at least one notifier", d.Get("name").(string))
+	}
+
+	kept := map[string]bool{}
+	for _, point := range existing {
+		kept[point.Uid] = false
+	}
+
+	var result *multierror.Error
+	for _, point := range points {
+		if done, ok := kept[point.Uid]; ok && !done {
+			kept[point.Uid] = true
+			if err := client.UpdateContactPoint(point); err != nil {
+				result = multierror.Append(result, accessError(err, fmt.Sprintf("updating %s integration %s of contact point %s", point.Type, point.Uid, point.Name)))
+			}
+			continue
+		}
+
+		point.Uid = ""
+		uid, err := client.NewContactPoint(point)
+		if err != nil {
+			result = multierror.Append(result, accessError(err, fmt.Sprintf("creating %s integration of contact point %s", point.Type, point.Name)))
+			continue
+		}
+		point.Uid = uid
+	}
+
+	for _, point := range existing {
+		if kept[point.Uid] {
+			continue
+		}
+		if err := client.DeleteContactPoint(point.Uid); err != nil && !isNotFound(err) {
+			result = multierror.Append(result, accessError(err, fmt.Sprintf("deleting %s integration %s of contact point %s", point.Type, point.Uid, orgResourceID(d))))
+		}
+	}
+
+	i := 0
+	for _, notifier := range contactPointNotifiers {
+		blocks := d.Get(notifier.attribute).([]interface{})
+		for _, block := range blocks {
+			block.(map[string]interface{})["uid"] = points[i].Uid
+			i++
+		}
+		if len(blocks) > 0 {
+			d.Set(notifier.attribute, blocks)
+		}
+	}
+
+	return result.ErrorOrNil()
+}
@@ -0,0 +1,222 @@
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	gapi "github.com/nytm/go-grafana-api"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccContactPoint_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccContactPointCheckDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccContactPointConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccContactPointCheckIntegrations("grafana_contact_point.test", 2),
+					resource.TestCheckResourceAttr("grafana_contact_point.test", "email.#", "1"),
+					resource.TestCheckResourceAttr("grafana_contact_point.test", "email.0.addresses.#", "2"),
+					resource.TestCheckResourceAttr("grafana_contact_point.test", "slack.0.url", "https://hooks.slack.test/services/T0/B0/X"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccContactPointConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccContactPointCheckIntegrations("grafana_contact_point.test", 1),
+					resource.TestCheckResourceAttr("grafana_contact_point.test", "name", "terraform-acc-test-renamed"),
+					resource.TestCheckResourceAttr("grafana_contact_point.test", "slack.#", "0"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:            "grafana_contact_point.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"slack"},
+			},
+		},
+	})
+}
+
+func TestContactPoint_lifecycle(t *testing.T) {
+	var mu sync.Mutex
+	points := map[string]gapi.ContactPoint{}
+	created := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/frontend/settings":
+			w.Write([]byte(`{"buildInfo": {"version": "10.0.0"}}`))
+		case r.Method == "GET" && r.URL.Path == "/api/v1/provisioning/contact-points":
+			result := []gapi.ContactPoint{}
+			for i := 1; i <= created; i++ {
+				point, ok := points[fmt.Sprintf("uid-%d", i)]
+				if !ok || point.Name != r.URL.Query().Get("name") {
+					continue
+				}
+				if _, ok := point.Settings["url"]; ok && point.Type == "slack" {
+					point.Settings = map[string]interface{}{"url": redactedSetting, "recipient": point.Settings["recipient"]}
+				}
+				result = append(result, point)
+			}
+			json.NewEncoder(w).Encode(result)
+		case r.Method == "POST" && r.URL.Path == "/api/v1/provisioning/contact-points":
+			var point gapi.ContactPoint
+			if err := json.NewDecoder(r.Body).Decode(&point); err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			created++
+			point.Uid = fmt.Sprintf("uid-%d", created)
+			points[point.Uid] = point
+			w.WriteHeader(http.StatusAccepted)
+			json.NewEncoder(w).Encode(point)
+		case r.Method == "PUT" && strings.HasPrefix(r.URL.Path, "/api/v1/provisioning/contact-points/"):
+			var point gapi.ContactPoint
+			if err := json.NewDecoder(r.Body).Decode(&point); err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			points[strings.TrimPrefix(r.URL.Path, "/api/v1/provisioning/contact-points/")] = point
+			w.WriteHeader(http.StatusAccepted)
+		case r.Method == "DELETE" && strings.HasPrefix(r.URL.Path, "/api/v1/provisioning/contact-points/"):
+			delete(points, strings.TrimPrefix(r.URL.Path, "/api/v1/provisioning/contact-points/"))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	d := schema.TestResourceDataRaw(t, ResourceContactPoint().Schema, map[string]interface{}{
+		"name": "ops",
+		"email": []interface{}{
+			map[string]interface{}{
+				"addresses": []interface{}{"a@example.net", "b@example.net"},
+			},
+		},
+		"slack": []interface{}{
+			map[string]interface{}{
+				"url":       "https://hooks.slack.test/secret",
+				"recipient": "#ops",
+			},
+		},
+	})
+	if err := CreateContactPoint(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if d.Id() != "ops" {
+		t.Fatalf("expected id ops, got %q", d.Id())
+	}
+	if addresses := points["uid-1"].Settings["addresses"]; addresses != "a@example.net;b@example.net" {
+		t.Fatalf("expected addresses joined by semicolons, got %v", addresses)
+	}
+	if uid := d.Get("slack.0.uid").(string); uid != "uid-2" {
+		t.Fatalf("expected the slack integration to be uid-2, got %q", uid)
+	}
+	if url := d.Get("slack.0.url").(string); url != "https://hooks.slack.test/secret" {
+		t.Fatalf("expected the redacted url to be kept, got %q", url)
+	}
+	expected := []interface{}{"a@example.net", "b@example.net"}
+	if addresses := d.Get("email.0.addresses").([]interface{}); !reflect.DeepEqual(addresses, expected) {
+		t.Fatalf("expected addresses %v, got %v", expected, addresses)
+	}
+
+	// Removing the email integration deletes it, and the slack one is
+	// updated in place.
+	d.Set("email", []interface{}{})
+	if err := UpdateContactPoint(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if _, ok := points["uid-1"]; ok {
+		t.Fatalf("expected the email integration to be deleted")
+	}
+	if created != 2 || d.Get("slack.0.uid").(string) != "uid-2" {
+		t.Fatalf("expected the slack integration to be updated in place, got %d created and uid %q", created, d.Get("slack.0.uid"))
+	}
+
+	if err := DeleteContactPoint(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(points) != 0 {
+		t.Fatalf("expected all integrations to be deleted, got %v", points)
+	}
+}
+
+func testAccContactPointCheckIntegrations(rn string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		client := testAccProvider.Meta().(*client).gapi
+		points, err := client.ContactPointsByName(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error getting contact point: %s", err)
+		}
+		if len(points) != count {
+			return fmt.Errorf("expected %d integrations, got %d", count, len(points))
+		}
+
+		return nil
+	}
+}
+
+func testAccContactPointCheckDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*client).gapi
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "grafana_contact_point" {
+			continue
+		}
+		points, err := client.ContactPointsByName(rs.Primary.ID)
+		if err == nil && len(points) > 0 {
+			return fmt.Errorf("contact point %s still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+const testAccContactPointConfig_basic = `
+resource "grafana_contact_point" "test" {
+    name = "terraform-acc-test"
+
+    email {
+        addresses    = ["one@example.net", "two@example.net"]
+        single_email = true
+    }
+
+    slack {
+        url       = "https://hooks.slack.test/services/T0/B0/X"
+        recipient = "#alerts"
+    }
+}
+`
+
+const testAccContactPointConfig_update = `
+resource "grafana_contact_point" "test" {
+    name = "terraform-acc-test-renamed"
+
+    email {
+        addresses = ["one@example.net"]
+        subject   = "{{ .CommonLabels.alertname }}"
+    }
+}
+`
@@ -0,0 +1,239 @@
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	gapi "github.com/nytm/go-grafana-api"
+)
+
+func ResourceCorrelation() *schema.Resource {
+	return &schema.Resource{
+		Create: CreateCorrelation,
+		Read:   ReadCorrelation,
+		Update: UpdateCorrelation,
+		Delete: DeleteCorrelation,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDSchema(),
+
+			"source_uid": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"target_uid": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"label": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"field": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"target_json": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				StateFunc:    normalizeDataSourceJSON,
+				ValidateFunc: validateDataSourceJSON,
+			},
+
+			"transformation": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateStringIn("regex", "logfmt"),
+						},
+
+						"expression": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"field": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"map_value": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"uid": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func CreateCorrelation(d *schema.ResourceData, meta interface{}) error {
+	if err := meta.(*client).requireVersion("grafana_correlation", "10.0.0"); err != nil {
+		return err
+	}
+
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	sourceUID := d.Get("source_uid").(string)
+	resp, err := client.NewCorrelation(sourceUID, makeCorrelation(d))
+	if err != nil {
+		return accessError(err, fmt.Sprintf("creating correlation of data source %s", sourceUID))
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", sourceUID, resp.Uid))
+
+	return ReadCorrelation(d, meta)
+}
+
+func ReadCorrelation(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	sourceUID, uid, err := parseCorrelationID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	correlation, err := client.Correlation(sourceUID, uid)
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] removing correlation %s from state because it no longer exists in grafana", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return accessError(err, fmt.Sprintf("reading correlation %s", d.Id()))
+	}
+
+	targetJSON, err := json.Marshal(correlation.Config.Target)
+	if err != nil {
+		return err
+	}
+
+	transformations := make([]interface{}, 0, len(correlation.Config.Transformations))
+	for _, transformation := range correlation.Config.Transformations {
+		transformations = append(transformations, map[string]interface{}{
+			"type":       transformation.Type,
+			"expression": transformation.Expression,
+			"field":      transformation.Field,
+			"map_value":  transformation.MapValue,
+		})
+	}
+
+	d.Set("source_uid", sourceUID)
+	d.Set("target_uid", correlation.TargetUid)
+	d.Set("uid", uid)
+	d.Set("label", correlation.Label)
+	d.Set("description", correlation.Description)
+	d.Set("field", correlation.Config.Field)
+	d.Set("target_json", string(targetJSON))
+	d.Set("transformation", transformations)
+
+	return nil
+}
+
+func UpdateCorrelation(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	sourceUID, uid, err := parseCorrelationID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.UpdateCorrelation(sourceUID, uid, makeCorrelation(d)); err != nil {
+		return accessError(err, fmt.Sprintf("updating correlation %s", d.Id()))
+	}
+
+	return ReadCorrelation(d, meta)
+}
+
+func DeleteCorrelation(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	sourceUID, uid, err := parseCorrelationID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	err = client.DeleteCorrelation(sourceUID, uid)
+	if err != nil && !isNotFound(err) {
+		return accessError(err, fmt.Sprintf("deleting correlation %s", d.Id()))
+	}
+
+	return nil
+}
+
+func makeCorrelation(d *schema.ResourceData) gapi.Correlation {
+	target := map[string]interface{}{}
+	// The validate function takes care of invalid JSON.
+	json.Unmarshal([]byte(d.Get("target_json").(string)), &target)
+
+	var transformations []gapi.CorrelationTransformation
+	for _, t := range d.Get("transformation").([]interface{}) {
+		t := t.(map[string]interface{})
+		transformations = append(transformations, gapi.CorrelationTransformation{
+			Type:       t["type"].(string),
+			Expression: t["expression"].(string),
+			Field:      t["field"].(string),
+			MapValue:   t["map_value"].(string),
+		})
+	}
+
+	return gapi.Correlation{
+		TargetUid:   d.Get("target_uid").(string),
+		Label:       d.Get("label").(string),
+		Description: d.Get("description").(string),
+		Config: gapi.CorrelationConfig{
+			Type:            "query",
+			Field:           d.Get("field").(string),
+			Target:          target,
+			Transformations: transformations,
+		},
+	}
+}
+
+// parseCorrelationID splits the "sourceUID:uid" ID of a correlation.
+func parseCorrelationID(id string) (string, string, error) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("Invalid id: %#v, expected sourceUID:uid", id)
+	}
+	return parts[0], parts[1], nil
+}
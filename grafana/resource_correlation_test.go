@@ -0,0 +1,123 @@
+package grafana
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCorrelation_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCorrelationCheckDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCorrelationConfig("Trace"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCorrelationCheckExists("grafana_correlation.test", "Trace"),
+					resource.TestCheckResourceAttr("grafana_correlation.test", "transformation.0.type", "regex"),
+					resource.TestCheckResourceAttrSet("grafana_correlation.test", "uid"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCorrelationConfig("Show the trace"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCorrelationCheckExists("grafana_correlation.test", "Show the trace"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "grafana_correlation.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestParseCorrelationID(t *testing.T) {
+	sourceUID, uid, err := parseCorrelationID("loki:abc")
+	if err != nil || sourceUID != "loki" || uid != "abc" {
+		t.Fatalf("unexpected result %q, %q, %v", sourceUID, uid, err)
+	}
+	for _, id := range []string{"loki", "loki:", ":abc", "a:b:c"} {
+		if _, _, err := parseCorrelationID(id); err == nil {
+			t.Errorf("expected an error for %q", id)
+		}
+	}
+}
+
+func testAccCorrelationCheckExists(rn, label string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		sourceUID, uid, err := parseCorrelationID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		client := testAccProvider.Meta().(*client).gapi
+		correlation, err := client.Correlation(sourceUID, uid)
+		if err != nil {
+			return fmt.Errorf("error getting correlation: %s", err)
+		}
+		if correlation.Label != label {
+			return fmt.Errorf("expected correlation label %q, got %q", label, correlation.Label)
+		}
+
+		return nil
+	}
+}
+
+func testAccCorrelationCheckDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*client).gapi
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "grafana_correlation" {
+			continue
+		}
+		sourceUID, uid, err := parseCorrelationID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if _, err := client.Correlation(sourceUID, uid); err == nil {
+			return fmt.Errorf("correlation %s still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccCorrelationConfig(label string) string {
+	return fmt.Sprintf(`
+resource "grafana_data_source" "loki" {
+    uid  = "tf-acc-test-correlation-loki"
+    type = "loki"
+    name = "terraform-acc-test-correlation-loki"
+    url  = "http://terraform-acc-test.invalid/"
+}
+
+resource "grafana_data_source" "tempo" {
+    uid  = "tf-acc-test-correlation-tempo"
+    type = "tempo"
+    name = "terraform-acc-test-correlation-tempo"
+    url  = "http://terraform-acc-test.invalid/"
+}
+
+resource "grafana_correlation" "test" {
+    source_uid  = "${grafana_data_source.loki.uid}"
+    target_uid  = "${grafana_data_source.tempo.uid}"
+    label       = %q
+    field       = "traceID"
+    target_json = "{\"query\": \"$${traceID}\"}"
+
+    transformation {
+        type       = "regex"
+        expression = "traceID=(\\w+)"
+        field      = "Line"
+    }
+}
+`, label)
+}
@@ -4,64 +4,186 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"math"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
-
 	gapi "github.com/nytm/go-grafana-api"
 )
 
 func ResourceDashboard() *schema.Resource {
 	return &schema.Resource{
 		Create: CreateDashboard,
+		Update: UpdateDashboard,
 		Delete: DeleteDashboard,
 		Read:   ReadDashboard,
+		Importer: &schema.ResourceImporter{
+			State: ImportDashboard,
+		},
 
 		Schema: map[string]*schema.Schema{
+			"org_id": orgIDSchema(),
+
+			"uid": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"dashboard_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"folder": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"overwrite": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"message": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"strict_data_sources": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			// saved_version is the version Terraform last saved, which isn't
+			// refreshed from Grafana like version, so that changes made in
+			// Grafana since can be told from it.
+			"saved_version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
 			"slug": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
 			},
 
+			"url": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"config_json": &schema.Schema{
-				Type:         schema.TypeString,
-				Required:     true,
-				ForceNew:     true,
-				StateFunc:    NormalizeDashboardConfigJSON,
-				ValidateFunc: ValidateDashboardConfigJSON,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ConflictsWith:    []string{"gnet_id"},
+				StateFunc:        NormalizeDashboardConfigJSON,
+				ValidateFunc:     ValidateDashboardConfigJSON,
+				DiffSuppressFunc: suppressDashboardConfigJSONDiff,
+			},
+
+			"gnet_id": &schema.Schema{
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ConflictsWith: []string{"config_json"},
+			},
+
+			"gnet_revision": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+
+			"inputs": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
 		},
 	}
 }
 
 func CreateDashboard(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*gapi.Client)
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
 
-	model := prepareDashboardModel(d.Get("config_json").(string))
+	var uid, slug string
+	if d.Get("gnet_id").(int) > 0 {
+		uid, slug, err = importDashboard(d, client, "", 0)
+	} else {
+		if d.Get("config_json").(string) == "" {
+			return fmt.Errorf("One of config_json or gnet_id must be set")
+		}
 
-	resp, err := client.SaveDashboard(model, false)
+		model := prepareDashboardModel(d.Get("config_json").(string))
+		if err := checkDashboardDataSources(client, model, d.Get("strict_data_sources").(bool)); err != nil {
+			return err
+		}
+
+		var resp *gapi.DashboardSaveResponse
+		resp, err = client.NewDashboard(gapi.NewDashboard{
+			Model:     model,
+			FolderUid: d.Get("folder").(string),
+			Message:   d.Get("message").(string),
+			Overwrite: d.Get("overwrite").(bool),
+		})
+		if resp != nil {
+			uid, slug = resp.Uid, resp.Slug
+		}
+	}
+	if statusCode(err) == http.StatusPreconditionFailed && statusReason(err) == "name-exists" {
+		return fmt.Errorf("Error creating dashboard: %s (set overwrite to true to save over the existing dashboard)", err)
+	}
 	if err != nil {
-		return err
+		return accessError(err, "creating dashboard")
 	}
 
-	d.SetId(resp.Slug)
+	// Grafana servers before 5.0 have no UIDs, and identify dashboards by
+	// their slug.
+	if uid != "" {
+		d.SetId(uid)
+	} else {
+		d.SetId(slug)
+	}
+	d.Set("uid", uid)
 
-	return ReadDashboard(d, meta)
+	return readSavedDashboard(d, meta)
 }
 
 func ReadDashboard(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*gapi.Client)
-
-	slug := d.Id()
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
 
-	dashboard, err := client.Dashboard(slug)
+	var dashboard *gapi.Dashboard
+	if d.Get("uid").(string) == "" {
+		// Dashboards created by earlier versions of this provider are
+		// identified by their slug, until they are read for the first time.
+		dashboard, err = client.Dashboard(d.Id())
+	} else {
+		dashboard, err = client.DashboardByUID(d.Id())
+	}
 	if err != nil {
-		if err.Error() == "404 Not Found" {
-			log.Printf("[WARN] removing dashboard %s from state because it no longer exists in grafana", slug)
+		if isNotFound(err) {
+			log.Printf("[WARN] removing dashboard %s from state because it no longer exists in grafana", d.Id())
 			d.SetId("")
 			return nil
 		}
 
-		return err
+		return accessError(err, fmt.Sprintf("reading dashboard %s", d.Id()))
 	}
 
 	configJSONBytes, err := json.Marshal(dashboard.Model)
@@ -71,18 +193,310 @@ func ReadDashboard(d *schema.ResourceData, meta interface{}) error {
 
 	configJSON := NormalizeDashboardConfigJSON(string(configJSONBytes))
 
-	d.SetId(dashboard.Meta.Slug)
+	uid, _ := dashboard.Model["uid"].(string)
+	id, _ := dashboard.Model["id"].(float64)
+
+	// Dashboards keep being identified by their slug on Grafana servers
+	// before 5.0, which have no UIDs.
+	if uid != "" {
+		d.SetId(uid)
+	}
+	d.Set("uid", uid)
+	d.Set("dashboard_id", int64(id))
+	d.Set("folder", dashboard.Meta.FolderUid)
+	d.Set("version", dashboard.Meta.Version)
+	// Imported dashboards haven't been saved by Terraform yet, and are
+	// saved over the version they were imported at.
+	if d.Get("saved_version").(int) == 0 {
+		d.Set("saved_version", dashboard.Meta.Version)
+	}
 	d.Set("slug", dashboard.Meta.Slug)
+	d.Set("url", dashboard.Meta.Url)
 	d.Set("config_json", configJSON)
 
 	return nil
 }
 
+func UpdateDashboard(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	// Grafana refuses to save over a dashboard whose version changed since
+	// Terraform last saved it, unless told to overwrite it. The version
+	// refreshed from Grafana would hide the changes made there since.
+	version := d.Get("saved_version").(int)
+	if version == 0 {
+		version = d.Get("version").(int)
+	}
+
+	uid := d.Get("uid").(string)
+	var slug string
+	if d.Get("gnet_id").(int) > 0 {
+		_, slug, err = importDashboard(d, client, uid, version)
+	} else {
+		model := prepareDashboardModel(d.Get("config_json").(string))
+		if err := checkDashboardDataSources(client, model, d.Get("strict_data_sources").(bool)); err != nil {
+			return err
+		}
+		// Dashboards without a UID are saved over by their ID, or Grafana
+		// would save them as new dashboards.
+		if uid != "" {
+			model["uid"] = uid
+		} else {
+			model["id"] = d.Get("dashboard_id").(int)
+		}
+		model["version"] = version
+
+		var resp *gapi.DashboardSaveResponse
+		resp, err = client.NewDashboard(gapi.NewDashboard{
+			Model:     model,
+			FolderUid: d.Get("folder").(string),
+			Message:   d.Get("message").(string),
+			Overwrite: d.Get("overwrite").(bool),
+		})
+		if resp != nil {
+			slug = resp.Slug
+		}
+	}
+	// Grafana refuses to save with a 412 both when the dashboard changed
+	// since it was read and when another dashboard of the folder has the
+	// same title, telling them apart in the response body.
+	if statusCode(err) == http.StatusPreconditionFailed {
+		switch statusReason(err) {
+		case "version-mismatch":
+			return fmt.Errorf("Error updating dashboard %s: %s (it was changed in Grafana since Terraform saved version %d; review the changes, then set overwrite to true to save over them)", d.Id(), err, version)
+		case "name-exists":
+			return fmt.Errorf("Error updating dashboard %s: %s (another dashboard in the folder has the same title; rename one of them, or set overwrite to true to replace the other one)", d.Id(), err)
+		}
+	}
+	if err != nil {
+		return accessError(err, fmt.Sprintf("updating dashboard %s", d.Id()))
+	}
+
+	// The slug of dashboards without a UID changes along with their title.
+	if uid == "" && slug != "" {
+		d.SetId(slug)
+	}
+
+	return readSavedDashboard(d, meta)
+}
+
+// readSavedDashboard reads a dashboard Terraform just saved, and records
+// its version as the one to save over next time.
+func readSavedDashboard(d *schema.ResourceData, meta interface{}) error {
+	if err := ReadDashboard(d, meta); err != nil {
+		return err
+	}
+	d.Set("saved_version", d.Get("version"))
+	return nil
+}
+
+// importDashboard imports the revision of the grafana.com dashboard set by
+// gnet_id and gnet_revision, as Grafana's web UI does, and returns its UID and
+// slug. The dashboard is saved over the one with the given UID and version,
+// or as a new dashboard when uid is empty.
+func importDashboard(d *schema.ResourceData, client *gapi.Client, uid string, version int) (string, string, error) {
+	gnetID := d.Get("gnet_id").(int)
+	revision := d.Get("gnet_revision").(int)
+	if revision <= 0 {
+		return "", "", fmt.Errorf("gnet_revision must be set along with gnet_id")
+	}
+
+	model, err := client.GnetDashboard(int64(gnetID), int64(revision))
+	if err != nil {
+		return "", "", actionError(err, fmt.Sprintf("downloading revision %d of grafana.com dashboard %d", revision, gnetID))
+	}
+
+	inputs, err := dashboardImportInputs(model, d.Get("inputs").(map[string]interface{}))
+	if err != nil {
+		return "", "", err
+	}
+
+	delete(model, "id")
+	if uid != "" {
+		model["uid"] = uid
+	}
+	model["version"] = version
+
+	resp, err := client.ImportDashboard(gapi.DashboardImport{
+		Dashboard: model,
+		Inputs:    inputs,
+		FolderUid: d.Get("folder").(string),
+		Overwrite: d.Get("overwrite").(bool),
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	return resp.Uid, resp.Slug, nil
+}
+
+// dashboardImportInputs matches the inputs declared in the __inputs of a
+// dashboard shared on grafana.com with the values given for them. Constants
+// default to the value they are shared with.
+func dashboardImportInputs(model map[string]interface{}, values map[string]interface{}) ([]gapi.DashboardImportInput, error) {
+	declared, _ := model["__inputs"].([]interface{})
+
+	inputs := []gapi.DashboardImportInput{}
+	names := map[string]bool{}
+	var missing []string
+	for _, input := range declared {
+		input, _ := input.(map[string]interface{})
+		name, _ := input["name"].(string)
+		inputType, _ := input["type"].(string)
+		pluginID, _ := input["pluginId"].(string)
+		names[name] = true
+
+		value, ok := values[name].(string)
+		if !ok && inputType == "constant" {
+			value, ok = input["value"].(string)
+		}
+		if !ok {
+			missing = append(missing, name)
+			continue
+		}
+
+		inputs = append(inputs, gapi.DashboardImportInput{
+			Name:     name,
+			Type:     inputType,
+			PluginId: pluginID,
+			Value:    value,
+		})
+	}
+
+	var unknown []string
+	for name := range values {
+		if !names[name] {
+			unknown = append(unknown, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("The dashboard needs values for the inputs %s", strings.Join(missing, ", "))
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("The dashboard has no inputs named %s", strings.Join(unknown, ", "))
+	}
+
+	return inputs, nil
+}
+
+// builtInDataSourceUIDs are the UIDs of the data sources every Grafana
+// server has, which aren't listed by the data sources API.
+var builtInDataSourceUIDs = map[string]bool{
+	"grafana":         true,
+	"-- Grafana --":   true,
+	"-- Mixed --":     true,
+	"-- Dashboard --": true,
+}
+
+// checkDashboardDataSources looks up the data sources that the panels,
+// queries, variables and annotations of a dashboard refer to by UID, since
+// Grafana saves dashboards referring to missing data sources without
+// complaint. Missing data sources fail the save when strict, and are logged
+// otherwise.
+func checkDashboardDataSources(client *gapi.Client, model map[string]interface{}, strict bool) error {
+	uids := dashboardDataSourceUIDs(model)
+	if len(uids) == 0 {
+		return nil
+	}
+
+	dataSources, err := client.DataSources()
+	if err != nil {
+		return accessError(err, "listing data sources")
+	}
+	exists := map[string]bool{}
+	for _, dataSource := range dataSources {
+		exists[dataSource.Uid] = true
+	}
+
+	var missing []string
+	for _, uid := range uids {
+		if !exists[uid] {
+			missing = append(missing, uid)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	if strict {
+		return fmt.Errorf("Data sources referred to by the dashboard not found in grafana: %s", strings.Join(missing, ", "))
+	}
+	log.Printf("[WARN] data sources referred to by dashboard %q not found in grafana: %s", model["title"], strings.Join(missing, ", "))
+	return nil
+}
+
+// dashboardDataSourceUIDs returns the UIDs of the data sources a dashboard
+// refers to, leaving out built-in data sources and template variables such
+// as ${DS_PROMETHEUS}. Data sources referred to by name, as dashboards saved
+// before Grafana 8.3 do, aren't returned.
+func dashboardDataSourceUIDs(model map[string]interface{}) []string {
+	found := map[string]bool{}
+	var walk func(value interface{})
+	walk = func(value interface{}) {
+		switch value := value.(type) {
+		case map[string]interface{}:
+			for key, v := range value {
+				if ref, ok := v.(map[string]interface{}); ok && key == "datasource" {
+					uid, _ := ref["uid"].(string)
+					if uid != "" && !builtInDataSourceUIDs[uid] && !strings.Contains(uid, "$") {
+						found[uid] = true
+					}
+				}
+				walk(v)
+			}
+		case []interface{}:
+			for _, v := range value {
+				walk(v)
+			}
+		}
+	}
+	walk(model)
+
+	uids := make([]string, 0, len(found))
+	for uid := range found {
+		uids = append(uids, uid)
+	}
+	sort.Strings(uids)
+	return uids
+}
+
+// ImportDashboard imports a dashboard by its UID. Its configuration is
+// read from Grafana, normalized in the same way as config_json.
+func ImportDashboard(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	// ReadDashboard looks dashboards without a uid up by their slug.
+	d.Set("uid", d.Id())
+
+	// The arguments Grafana doesn't have are set to their defaults, or
+	// they'd show up as changes in the first plan after importing.
+	d.Set("overwrite", false)
+	d.Set("message", "")
+	d.Set("strict_data_sources", false)
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func DeleteDashboard(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*gapi.Client)
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	if d.Get("uid").(string) == "" {
+		err = client.DeleteDashboard(d.Id())
+	} else {
+		err = client.DeleteDashboardByUID(d.Id())
+	}
+	if err != nil && !isNotFound(err) {
+		return accessError(err, fmt.Sprintf("deleting dashboard %s", d.Id()))
+	}
 
-	slug := d.Id()
-	return client.DeleteDashboard(slug)
+	return nil
 }
 
 func prepareDashboardModel(configJSON string) map[string]interface{} {
@@ -101,6 +515,14 @@ func prepareDashboardModel(configJSON string) map[string]interface{} {
 	return configMap
 }
 
+// dashboardUIDPattern matches the UIDs Grafana accepts for dashboards.
+var dashboardUIDPattern = regexp.MustCompile(`^[a-zA-Z0-9\-_]{1,40}$`)
+
+// ValidateDashboardConfigJSON checks what Grafana would otherwise reject
+// when the dashboard is saved: the JSON must be an object with a title, and
+// the ids of panels must be unique positive integers. It must not set a uid
+// either, since Grafana assigns the uids of the dashboards this provider
+// saves.
 func ValidateDashboardConfigJSON(configI interface{}, k string) ([]string, []error) {
 	configJSON := configI.(string)
 	configMap := map[string]interface{}{}
@@ -108,7 +530,46 @@ func ValidateDashboardConfigJSON(configI interface{}, k string) ([]string, []err
 	if err != nil {
 		return nil, []error{err}
 	}
-	return nil, nil
+
+	var errors []error
+	if title, _ := configMap["title"].(string); strings.TrimSpace(title) == "" {
+		errors = append(errors, fmt.Errorf("%s must have a title", k))
+	}
+	if uid, ok := configMap["uid"]; ok && uid != nil {
+		errors = append(errors, fmt.Errorf("%s must not have a uid, since Grafana assigns the uids of the dashboards Terraform saves, got %v", k, uid))
+	}
+	errors = append(errors, validateDashboardPanelIDs(k, configMap["panels"], map[float64]bool{})...)
+
+	return nil, errors
+}
+
+// validateDashboardPanelIDs checks the ids of panels, including the panels
+// nested in collapsed rows, which share the dashboard's ids. Panels without
+// an id are given one by Grafana.
+func validateDashboardPanelIDs(k string, panels interface{}, seen map[float64]bool) []error {
+	var errors []error
+	list, _ := panels.([]interface{})
+	for _, panel := range list {
+		panel, ok := panel.(map[string]interface{})
+		if !ok {
+			errors = append(errors, fmt.Errorf("%s has a panel that isn't a JSON object", k))
+			continue
+		}
+
+		if id, ok := panel["id"]; ok && id != nil {
+			id, _ := id.(float64)
+			switch {
+			case id <= 0 || id != math.Trunc(id):
+				errors = append(errors, fmt.Errorf("%s has a panel with the invalid id %v: it must be a positive integer", k, panel["id"]))
+			case seen[id]:
+				errors = append(errors, fmt.Errorf("%s has several panels with the id %v", k, id))
+			}
+			seen[id] = true
+		}
+
+		errors = append(errors, validateDashboardPanelIDs(k, panel["panels"], seen)...)
+	}
+	return errors
 }
 
 func NormalizeDashboardConfigJSON(configI interface{}) string {
@@ -128,6 +589,8 @@ func NormalizeDashboardConfigJSON(configI interface{}) string {
 	// Only exists in 5.0+
 	delete(configMap, "uid")
 
+	stripLibraryPanelMeta(configMap["panels"])
+
 	ret, err := json.Marshal(configMap)
 	if err != nil {
 		// Should never happen.
@@ -136,3 +599,102 @@ func NormalizeDashboardConfigJSON(configI interface{}) string {
 
 	return string(ret)
 }
+
+// stripLibraryPanelMeta removes what Grafana adds to the references to
+// library panels when it returns a dashboard, such as the library panel's
+// version and metadata, so that only the uid and name that identify the
+// library panel remain. Panels nested in collapsed rows are stripped too.
+func stripLibraryPanelMeta(panels interface{}) {
+	list, _ := panels.([]interface{})
+	for _, panel := range list {
+		panel, ok := panel.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if ref, ok := panel["libraryPanel"].(map[string]interface{}); ok {
+			for key := range ref {
+				if key != "uid" && key != "name" {
+					delete(ref, key)
+				}
+			}
+		}
+		stripLibraryPanelMeta(panel["panels"])
+	}
+}
+
+// dashboardDefaults are the values Grafana gives dashboard properties that
+// aren't set when the dashboard is saved, besides empty values.
+var dashboardDefaults = map[string]interface{}{
+	"editable": true,
+	"style":    "dark",
+	"time": map[string]interface{}{
+		"from": "now-6h",
+		"to":   "now",
+	},
+}
+
+// suppressDashboardConfigJSONDiff ignores the differences between the
+// normalized dashboard JSON read from Grafana and the one in the
+// configuration that come from Grafana filling in properties on save.
+// Properties set in the configuration are always compared.
+func suppressDashboardConfigJSONDiff(k, old, new string, d *schema.ResourceData) bool {
+	oldMap := map[string]interface{}{}
+	newMap := map[string]interface{}{}
+	if json.Unmarshal([]byte(old), &oldMap) != nil || json.Unmarshal([]byte(new), &newMap) != nil {
+		return false
+	}
+
+	for key, value := range oldMap {
+		if _, ok := newMap[key]; !ok && isDashboardDefault(key, value) {
+			delete(oldMap, key)
+		}
+	}
+
+	return reflect.DeepEqual(oldMap, newMap)
+}
+
+// isDashboardDefault reports whether value is what Grafana sets the
+// dashboard property key to when it isn't given.
+func isDashboardDefault(key string, value interface{}) bool {
+	switch key {
+	case "schemaVersion":
+		// Grafana migrates dashboards to its current schema version.
+		return true
+	case "annotations":
+		// Grafana adds its built-in annotations to every dashboard.
+		annotations, _ := value.(map[string]interface{})
+		list, _ := annotations["list"].([]interface{})
+		for _, annotation := range list {
+			annotation, _ := annotation.(map[string]interface{})
+			if builtIn, _ := annotation["builtIn"].(float64); builtIn != 1 {
+				return false
+			}
+		}
+		return len(annotations) <= 1
+	case "templating":
+		templating, _ := value.(map[string]interface{})
+		list, _ := templating["list"].([]interface{})
+		return len(list) == 0 && len(templating) <= 1
+	}
+
+	if def, ok := dashboardDefaults[key]; ok && reflect.DeepEqual(value, def) {
+		return true
+	}
+
+	switch value := value.(type) {
+	case nil:
+		return true
+	case bool:
+		return !value
+	case float64:
+		return value == 0
+	case string:
+		return value == ""
+	case []interface{}:
+		return len(value) == 0
+	case map[string]interface{}:
+		return len(value) == 0
+	}
+
+	return false
+}
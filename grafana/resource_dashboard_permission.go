@@ -0,0 +1,91 @@
+package grafana
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func ResourceDashboardPermission() *schema.Resource {
+	return &schema.Resource{
+		Create: UpdateDashboardPermission,
+		Read:   ReadDashboardPermission,
+		Update: UpdateDashboardPermission,
+		Delete: DeleteDashboardPermission,
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDSchema(),
+
+			"dashboard_uid": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"permissions": permissionsSchema(),
+		},
+	}
+}
+
+// UpdateDashboardPermission replaces all the permissions of the dashboard
+// with the configured ones. Permissions inherited from the dashboard's folder
+// are left alone.
+func UpdateDashboardPermission(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	items, err := makePermissionItems(d)
+	if err != nil {
+		return err
+	}
+
+	uid := d.Get("dashboard_uid").(string)
+	if err := client.UpdateDashboardPermissions(uid, items); err != nil {
+		return accessError(err, fmt.Sprintf("updating permissions of dashboard %s", uid))
+	}
+
+	d.SetId(uid)
+
+	return ReadDashboardPermission(d, meta)
+}
+
+func ReadDashboardPermission(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	items, err := client.DashboardPermissions(d.Id())
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] removing permissions of dashboard %s from state because the dashboard no longer exists in grafana", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return accessError(err, fmt.Sprintf("reading permissions of dashboard %s", d.Id()))
+	}
+
+	d.Set("dashboard_uid", d.Id())
+	d.Set("permissions", flattenPermissionItems(items))
+
+	return nil
+}
+
+// DeleteDashboardPermission removes all the permissions of the dashboard, so
+// that it only has the ones inherited from its folder.
+func DeleteDashboardPermission(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	err = client.UpdateDashboardPermissions(d.Id(), nil)
+	if err != nil && !isNotFound(err) {
+		return accessError(err, fmt.Sprintf("removing permissions of dashboard %s", d.Id()))
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package grafana
+
+import (
+	"fmt"
+	"testing"
+
+	gapi "github.com/nytm/go-grafana-api"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccDashboardPermission_basic(t *testing.T) {
+	var folder gapi.Folder
+	var dashboard gapi.Dashboard
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccFolderCheckDestroy(&folder),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccDashboardPermissionConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccFolderCheckExists("grafana_folder.test", &folder),
+					testAccDashboardCheckExists("grafana_dashboard.test", &dashboard),
+					testAccDashboardPermissionCheckItems("grafana_dashboard_permission.test", 2),
+					resource.TestCheckResourceAttr(
+						"grafana_dashboard_permission.test", "permissions.#", "2",
+					),
+				),
+			},
+		},
+	})
+}
+
+// testAccDashboardPermissionCheckItems checks the number of permissions of
+// a dashboard that aren't inherited from its folder.
+func testAccDashboardPermissionCheckItems(rn string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		client := testAccProvider.Meta().(*client).gapi
+		items, err := client.DashboardPermissions(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error getting dashboard permissions: %s", err)
+		}
+		own := 0
+		for _, item := range items {
+			if !item.Inherited {
+				own++
+			}
+		}
+		if own != count {
+			return fmt.Errorf("expected %d dashboard permissions, got %d: %v", count, own, items)
+		}
+
+		return nil
+	}
+}
+
+const testAccDashboardPermissionConfig_basic = `
+resource "grafana_folder" "test" {
+    title = "Terraform Acceptance Test Dashboard Permissions"
+}
+
+resource "grafana_dashboard" "test" {
+    folder      = "${grafana_folder.test.id}"
+    config_json = <<EOT
+{
+    "title": "Terraform Acceptance Test Dashboard Permissions"
+}
+EOT
+}
+
+resource "grafana_dashboard_permission" "test" {
+    dashboard_uid = "${grafana_dashboard.test.id}"
+
+    permissions {
+        role       = "Viewer"
+        permission = "Edit"
+    }
+
+    permissions {
+        user_id    = 1
+        permission = "Admin"
+    }
+}
+`
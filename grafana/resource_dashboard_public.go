@@ -0,0 +1,184 @@
+package grafana
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	gapi "github.com/nytm/go-grafana-api"
+)
+
+func ResourceDashboardPublic() *schema.Resource {
+	return &schema.Resource{
+		Create: CreateDashboardPublic,
+		Read:   ReadDashboardPublic,
+		Update: UpdateDashboardPublic,
+		Delete: DeleteDashboardPublic,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDSchema(),
+
+			"dashboard_uid": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"uid": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"access_token": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"is_enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"time_selection_enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"annotations_enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"public_url": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func CreateDashboardPublic(d *schema.ResourceData, meta interface{}) error {
+	if err := meta.(*client).requireVersion("grafana_dashboard_public", "10.2.0"); err != nil {
+		return err
+	}
+
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	dashboardUID := d.Get("dashboard_uid").(string)
+	publicDashboard := makePublicDashboard(d)
+	publicDashboard.Uid = d.Get("uid").(string)
+	publicDashboard.AccessToken = d.Get("access_token").(string)
+
+	resp, err := client.NewPublicDashboard(dashboardUID, publicDashboard)
+	if err != nil {
+		return accessError(err, fmt.Sprintf("sharing dashboard %s publicly", dashboardUID))
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", dashboardUID, resp.Uid))
+
+	return ReadDashboardPublic(d, meta)
+}
+
+func ReadDashboardPublic(d *schema.ResourceData, meta interface{}) error {
+	url := strings.TrimSuffix(meta.(*client).url, "/")
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	dashboardUID, uid, err := parseDashboardPublicID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	publicDashboard, err := client.PublicDashboard(dashboardUID)
+	if err != nil && !isNotFound(err) {
+		return accessError(err, fmt.Sprintf("reading public dashboard %s", d.Id()))
+	}
+	// The dashboard may also have been shared again since.
+	if err != nil || publicDashboard.Uid != uid {
+		log.Printf("[WARN] removing public dashboard %s from state because it no longer exists in grafana", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("dashboard_uid", dashboardUID)
+	d.Set("uid", publicDashboard.Uid)
+	d.Set("access_token", publicDashboard.AccessToken)
+	d.Set("is_enabled", publicDashboard.IsEnabled)
+	d.Set("time_selection_enabled", publicDashboard.TimeSelectionEnabled)
+	d.Set("annotations_enabled", publicDashboard.AnnotationsEnabled)
+	d.Set("public_url", fmt.Sprintf("%s/public-dashboards/%s", url, publicDashboard.AccessToken))
+
+	return nil
+}
+
+func UpdateDashboardPublic(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	dashboardUID, uid, err := parseDashboardPublicID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.UpdatePublicDashboard(dashboardUID, uid, makePublicDashboard(d)); err != nil {
+		return accessError(err, fmt.Sprintf("updating public dashboard %s", d.Id()))
+	}
+
+	return ReadDashboardPublic(d, meta)
+}
+
+func DeleteDashboardPublic(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	dashboardUID, uid, err := parseDashboardPublicID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	err = client.DeletePublicDashboard(dashboardUID, uid)
+	if err != nil && !isNotFound(err) {
+		return accessError(err, fmt.Sprintf("deleting public dashboard %s", d.Id()))
+	}
+
+	return nil
+}
+
+func makePublicDashboard(d *schema.ResourceData) gapi.PublicDashboard {
+	return gapi.PublicDashboard{
+		IsEnabled:            d.Get("is_enabled").(bool),
+		TimeSelectionEnabled: d.Get("time_selection_enabled").(bool),
+		AnnotationsEnabled:   d.Get("annotations_enabled").(bool),
+		Share:                "public",
+	}
+}
+
+// parseDashboardPublicID splits the "dashboardUID:uid" ID of a public
+// dashboard.
+func parseDashboardPublicID(id string) (string, string, error) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("Invalid id: %#v, expected dashboardUID:uid", id)
+	}
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,95 @@
+package grafana
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	gapi "github.com/nytm/go-grafana-api"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccDashboardPublic_basic(t *testing.T) {
+	var dashboard gapi.Dashboard
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccDashboardCheckDestroy(&dashboard),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccDashboardPublicConfig(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDashboardCheckExists("grafana_dashboard.test", &dashboard),
+					testAccDashboardPublicCheckEnabled("grafana_dashboard_public.test", true),
+					resource.TestMatchResourceAttr(
+						"grafana_dashboard_public.test", "public_url", regexp.MustCompile(`/public-dashboards/[0-9a-f]+$`),
+					),
+				),
+			},
+			resource.TestStep{
+				Config: testAccDashboardPublicConfig(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDashboardPublicCheckEnabled("grafana_dashboard_public.test", false),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "grafana_dashboard_public.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestParseDashboardPublicID(t *testing.T) {
+	dashboardUID, uid, err := parseDashboardPublicID("abc:def")
+	if err != nil || dashboardUID != "abc" || uid != "def" {
+		t.Fatalf("unexpected result %q, %q, %v", dashboardUID, uid, err)
+	}
+	for _, id := range []string{"abc", "abc:", ":def", "a:b:c"} {
+		if _, _, err := parseDashboardPublicID(id); err == nil {
+			t.Errorf("expected an error for %q", id)
+		}
+	}
+}
+
+func testAccDashboardPublicCheckEnabled(rn string, enabled bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		client := testAccProvider.Meta().(*client).gapi
+		publicDashboard, err := client.PublicDashboard(rs.Primary.Attributes["dashboard_uid"])
+		if err != nil {
+			return fmt.Errorf("error getting public dashboard: %s", err)
+		}
+		if publicDashboard.IsEnabled != enabled {
+			return fmt.Errorf("expected public dashboard to be enabled: %t, got %t", enabled, publicDashboard.IsEnabled)
+		}
+
+		return nil
+	}
+}
+
+func testAccDashboardPublicConfig(enabled bool) string {
+	return fmt.Sprintf(`
+resource "grafana_dashboard" "test" {
+    config_json = <<EOT
+{
+    "title": "Terraform Acceptance Test Public Dashboard"
+}
+EOT
+}
+
+resource "grafana_dashboard_public" "test" {
+    dashboard_uid          = "${grafana_dashboard.test.uid}"
+    is_enabled             = %t
+    time_selection_enabled = true
+}
+`, enabled)
+}
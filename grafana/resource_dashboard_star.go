@@ -0,0 +1,101 @@
+package grafana
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	gapi "github.com/nytm/go-grafana-api"
+)
+
+func ResourceDashboardStar() *schema.Resource {
+	return &schema.Resource{
+		Create: CreateDashboardStar,
+		Read:   ReadDashboardStar,
+		Delete: DeleteDashboardStar,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDSchema(),
+
+			"dashboard_uid": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func CreateDashboardStar(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	uid := d.Get("dashboard_uid").(string)
+	dashboard, err := client.DashboardByUID(uid)
+	if err != nil {
+		return accessError(err, fmt.Sprintf("reading dashboard %s", uid))
+	}
+
+	// Grafana refuses to star a dashboard twice.
+	if !dashboard.Meta.IsStarred {
+		err = client.StarDashboard(dashboardID(dashboard))
+		if err != nil && statusCode(err) != http.StatusConflict {
+			return accessError(err, fmt.Sprintf("starring dashboard %s", uid))
+		}
+	}
+
+	d.SetId(uid)
+
+	return ReadDashboardStar(d, meta)
+}
+
+func ReadDashboardStar(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	dashboard, err := client.DashboardByUID(d.Id())
+	if err != nil && !isNotFound(err) {
+		return accessError(err, fmt.Sprintf("reading dashboard %s", d.Id()))
+	}
+	if err != nil || !dashboard.Meta.IsStarred {
+		log.Printf("[WARN] removing star of dashboard %s from state because it no longer exists in grafana", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("dashboard_uid", d.Id())
+
+	return nil
+}
+
+func DeleteDashboardStar(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	dashboard, err := client.DashboardByUID(d.Id())
+	if err == nil && dashboard.Meta.IsStarred {
+		err = client.UnstarDashboard(dashboardID(dashboard))
+	}
+	if err != nil && !isNotFound(err) {
+		return accessError(err, fmt.Sprintf("unstarring dashboard %s", d.Id()))
+	}
+
+	return nil
+}
+
+// dashboardID returns the numeric ID of a dashboard, which some APIs still
+// identify dashboards by.
+func dashboardID(dashboard *gapi.Dashboard) int64 {
+	id, _ := dashboard.Model["id"].(float64)
+	return int64(id)
+}
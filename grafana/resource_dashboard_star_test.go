@@ -0,0 +1,78 @@
+package grafana
+
+import (
+	"fmt"
+	"testing"
+
+	gapi "github.com/nytm/go-grafana-api"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccDashboardStar_basic(t *testing.T) {
+	var dashboard gapi.Dashboard
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccDashboardCheckDestroy(&dashboard),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccDashboardStarConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccDashboardCheckExists("grafana_dashboard.test", &dashboard),
+					testAccDashboardStarCheckStarred("grafana_dashboard.test", true),
+					resource.TestCheckResourceAttrPair(
+						"grafana_dashboard_star.test", "id", "grafana_dashboard.test", "uid",
+					),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "grafana_dashboard_star.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			resource.TestStep{
+				Config: testAccDashboardStarConfig_unstarred,
+				Check:  testAccDashboardStarCheckStarred("grafana_dashboard.test", false),
+			},
+		},
+	})
+}
+
+func testAccDashboardStarCheckStarred(rn string, starred bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		client := testAccProvider.Meta().(*client).gapi
+		dashboard, err := client.DashboardByUID(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error getting dashboard: %s", err)
+		}
+		if dashboard.Meta.IsStarred != starred {
+			return fmt.Errorf("expected dashboard to be starred: %t, got %t", starred, dashboard.Meta.IsStarred)
+		}
+
+		return nil
+	}
+}
+
+const testAccDashboardStarConfig_unstarred = `
+resource "grafana_dashboard" "test" {
+    config_json = <<EOT
+{
+    "title": "Terraform Acceptance Test Star"
+}
+EOT
+}
+`
+
+const testAccDashboardStarConfig_basic = testAccDashboardStarConfig_unstarred + `
+resource "grafana_dashboard_star" "test" {
+    dashboard_uid = "${grafana_dashboard.test.uid}"
+}
+`
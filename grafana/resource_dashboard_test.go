@@ -1,13 +1,20 @@
 package grafana
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"regexp"
+	"strings"
 	"testing"
 
 	gapi "github.com/nytm/go-grafana-api"
 
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 )
 
@@ -23,11 +30,44 @@ func TestAccDashboard_basic(t *testing.T) {
 				Config: testAccDashboardConfig_basic,
 				Check: resource.ComposeTestCheckFunc(
 					testAccDashboardCheckExists("grafana_dashboard.test", &dashboard),
+					resource.TestCheckResourceAttrPair(
+						"grafana_dashboard.test", "id", "grafana_dashboard.test", "uid",
+					),
+					resource.TestCheckResourceAttr(
+						"grafana_dashboard.test", "slug", "terraform-acceptance-test",
+					),
 					resource.TestMatchResourceAttr(
-						"grafana_dashboard.test", "id", regexp.MustCompile(`terraform-acceptance-test.*`),
+						"grafana_dashboard.test", "url", regexp.MustCompile("^/d/[^/]+/terraform-acceptance-test$"),
+					),
+					resource.TestCheckResourceAttr(
+						"grafana_dashboard.test", "version", "1",
 					),
 				),
 			},
+			// Changing the dashboard updates it in place.
+			resource.TestStep{
+				Config: testAccDashboardConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccDashboardCheckExists("grafana_dashboard.test", &dashboard),
+					resource.TestCheckResourceAttrPair(
+						"grafana_dashboard.test", "id", "grafana_dashboard.test", "uid",
+					),
+					resource.TestCheckResourceAttr(
+						"grafana_dashboard.test", "slug", "terraform-acceptance-test-updated",
+					),
+					resource.TestCheckResourceAttr(
+						"grafana_dashboard.test", "version", "2",
+					),
+					testAccDashboardCheckMessage(&dashboard, "Rename the dashboard"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "grafana_dashboard.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Grafana keeps messages in the version history only.
+				ImportStateVerifyIgnore: []string{"message"},
+			},
 		},
 	})
 }
@@ -52,6 +92,445 @@ func TestAccDashboard_disappear(t *testing.T) {
 	})
 }
 
+func TestReadDashboard_slugID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/dashboards/db/legacy-dashboard" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{
+			"meta": {"slug": "legacy-dashboard"},
+			"dashboard": {"id": 7, "uid": "abc123", "version": 3, "title": "Legacy Dashboard"}
+		}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	// State written before dashboards were identified by UID.
+	d := schema.TestResourceDataRaw(t, ResourceDashboard().Schema, map[string]interface{}{
+		"config_json": `{"title": "Legacy Dashboard"}`,
+	})
+	d.SetId("legacy-dashboard")
+
+	if err := ReadDashboard(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Id() != "abc123" || d.Get("uid").(string) != "abc123" {
+		t.Fatalf("expected the dashboard to be identified by its UID, got id %q and uid %q", d.Id(), d.Get("uid"))
+	}
+	if d.Get("dashboard_id").(int) != 7 {
+		t.Fatalf("expected dashboard_id 7, got %d", d.Get("dashboard_id"))
+	}
+	if d.Get("slug").(string) != "legacy-dashboard" {
+		t.Fatalf("expected slug legacy-dashboard, got %q", d.Get("slug"))
+	}
+}
+
+func TestDashboard_withoutUID(t *testing.T) {
+	// Grafana servers before 5.0 identify dashboards by their slug only.
+	title := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		slug := strings.ToLower(strings.Replace(title, " ", "-", -1))
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/dashboards/db":
+			var save gapi.NewDashboard
+			if err := json.NewDecoder(r.Body).Decode(&save); err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			if _, ok := save.Model["uid"]; ok {
+				t.Errorf("expected no uid to be sent, got %v", save.Model["uid"])
+			}
+			// Without the ID of the dashboard, Grafana would save a new one.
+			if id, ok := save.Model["id"]; title == "" && ok {
+				t.Errorf("expected no id to be sent for a new dashboard, got %v", id)
+			} else if title != "" && id != float64(7) {
+				t.Errorf("expected the dashboard to be saved by its id 7, got %v", id)
+			}
+			title = save.Model["title"].(string)
+			slug = strings.ToLower(strings.Replace(title, " ", "-", -1))
+			fmt.Fprintf(w, `{"slug": %q, "status": "success", "version": 1}`, slug)
+		case r.Method == "GET" && r.URL.Path == "/api/dashboards/db/"+slug:
+			fmt.Fprintf(w, `{"meta": {"slug": %q}, "dashboard": {"id": 7, "version": 1, "title": %q}}`, slug, title)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	d := schema.TestResourceDataRaw(t, ResourceDashboard().Schema, map[string]interface{}{
+		"config_json": `{"title": "Legacy Dashboard"}`,
+	})
+	if err := CreateDashboard(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Id() != "legacy-dashboard" || d.Get("uid").(string) != "" {
+		t.Fatalf("expected the dashboard to be identified by its slug, got id %q and uid %q", d.Id(), d.Get("uid"))
+	}
+
+	d.Set("config_json", `{"title": "Renamed Dashboard"}`)
+	if err := UpdateDashboard(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Id() != "renamed-dashboard" {
+		t.Fatalf("expected the dashboard to be identified by its new slug, got %q", d.Id())
+	}
+}
+
+func TestImportDashboard(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/api/dashboards/uid/abc123" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"meta": {"version": 2, "slug": "dashboard"}, "dashboard": {"id": 7, "uid": "abc123", "version": 2, "title": "Dashboard"}}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	d := ResourceDashboard().Data(nil)
+	d.SetId("abc123")
+	if _, err := ImportDashboard(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := ReadDashboard(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	raw, err := config.NewRawConfig(map[string]interface{}{
+		"config_json": `{"title": "Dashboard"}`,
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	diff, err := ResourceDashboard().Diff(d.State(), terraform.NewResourceConfig(raw))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !diff.Empty() {
+		t.Fatalf("expected no changes after importing, got %v", diff.Attributes)
+	}
+}
+
+func TestValidateDashboardConfigJSON(t *testing.T) {
+	cases := []struct {
+		config string
+		valid  bool
+	}{
+		{`{"title": "a"}`, true},
+		{`{"title": "a", "id": 7, "version": 3}`, true},
+		{`{"title": "a", "uid": null, "id": null}`, true},
+		{`{"title": "a", "panels": [{"id": 1}, {"id": 2, "panels": [{"id": 3}]}, {"type": "text"}]}`, true},
+		{`not json`, false},
+		{`["a"]`, false},
+		{`{}`, false},
+		{`{"title": " "}`, false},
+		{`{"title": 1}`, false},
+		{`{"title": "a", "uid": "cIBgcSjkk"}`, false},
+		{`{"title": "a", "uid": 12}`, false},
+		{`{"title": "a", "panels": [{"id": 0}]}`, false},
+		{`{"title": "a", "panels": [{"id": 1.5}]}`, false},
+		{`{"title": "a", "panels": [{"id": "1"}]}`, false},
+		{`{"title": "a", "panels": [{"id": 1}, {"id": 2, "panels": [{"id": 1}]}]}`, false},
+		{`{"title": "a", "panels": ["panel"]}`, false},
+	}
+
+	for _, tc := range cases {
+		_, errs := ValidateDashboardConfigJSON(tc.config, "config_json")
+		if valid := len(errs) == 0; valid != tc.valid {
+			t.Errorf("ValidateDashboardConfigJSON(%s) returned %v, expected valid to be %t", tc.config, errs, tc.valid)
+		}
+	}
+}
+
+func TestDashboardDataSourceUIDs(t *testing.T) {
+	model := map[string]interface{}{}
+	err := json.Unmarshal([]byte(`{
+		"title": "Dashboard",
+		"annotations": {"list": [{"datasource": {"type": "grafana", "uid": "-- Grafana --"}}]},
+		"templating": {"list": [{"name": "ds", "datasource": {"uid": "loki"}}]},
+		"panels": [
+			{"datasource": {"type": "prometheus", "uid": "prom"}, "targets": [{"datasource": {"uid": "prom"}}]},
+			{"datasource": {"uid": "${DS_PROMETHEUS}"}},
+			{"datasource": "Legacy Name"},
+			{"type": "row", "panels": [{"datasource": {"uid": "tempo"}}]}
+		]
+	}`), &model)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := []string{"loki", "prom", "tempo"}
+	if got := dashboardDataSourceUIDs(model); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestCreateDashboard_strictDataSources(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/api/datasources" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`[{"id": 1, "uid": "prom", "name": "Prometheus"}]`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	d := schema.TestResourceDataRaw(t, ResourceDashboard().Schema, map[string]interface{}{
+		"config_json":         `{"title": "Dashboard", "panels": [{"datasource": {"uid": "prom"}}, {"datasource": {"uid": "loki"}}]}`,
+		"strict_data_sources": true,
+	})
+
+	err := CreateDashboard(d, c)
+	if err == nil || !strings.Contains(err.Error(), "loki") || strings.Contains(err.Error(), "prom") {
+		t.Fatalf("expected an error about the missing data source, got %v", err)
+	}
+}
+
+func TestNormalizeDashboardConfigJSON(t *testing.T) {
+	got := NormalizeDashboardConfigJSON(`{
+		"id": 7,
+		"uid": "abc123",
+		"version": 3,
+		"title": "Dashboard",
+		"panels": [
+			{"id": 1, "libraryPanel": {"uid": "lib1", "name": "Requests", "version": 2, "meta": {"folderUid": ""}}},
+			{"id": 2, "type": "row", "collapsed": true, "panels": [
+				{"id": 3, "libraryPanel": {"uid": "lib2", "name": "Errors", "description": ""}}
+			]}
+		]
+	}`)
+	expected := `{"panels":[{"id":1,"libraryPanel":{"name":"Requests","uid":"lib1"}},{"collapsed":true,"id":2,"panels":[{"id":3,"libraryPanel":{"name":"Errors","uid":"lib2"}}],"type":"row"}],"title":"Dashboard"}`
+	if got != expected {
+		t.Fatalf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestSuppressDashboardConfigJSONDiff(t *testing.T) {
+	cases := []struct {
+		old, new string
+		suppress bool
+	}{
+		{`{"title":"a"}`, `{"title":"a"}`, true},
+		{`{"title":"a"}`, `{"title":"b"}`, false},
+		// Properties filled in by Grafana on save.
+		{
+			`{"annotations":{"list":[{"builtIn":1,"name":"Annotations & Alerts"}]},"editable":true,"graphTooltip":0,"links":[],"panels":[],"schemaVersion":39,"style":"dark","tags":[],"templating":{"list":[]},"time":{"from":"now-6h","to":"now"},"timepicker":{},"timezone":"","title":"a"}`,
+			`{"title":"a"}`,
+			true,
+		},
+		// Properties set in the configuration are compared.
+		{`{"editable":true,"title":"a"}`, `{"editable":false,"title":"a"}`, false},
+		{`{"schemaVersion":39,"title":"a"}`, `{"schemaVersion":16,"title":"a"}`, false},
+		// Changes made outside of Terraform aren't defaults.
+		{`{"panels":[{"id":1}],"title":"a"}`, `{"title":"a"}`, false},
+		{`{"tags":["prod"],"title":"a"}`, `{"title":"a"}`, false},
+		{`{"time":{"from":"now-1h","to":"now"},"title":"a"}`, `{"title":"a"}`, false},
+		{`{"annotations":{"list":[{"name":"Deploys"}]},"title":"a"}`, `{"title":"a"}`, false},
+		{`{"templating":{"list":[{"name":"env"}]},"title":"a"}`, `{"title":"a"}`, false},
+		// Properties removed from the configuration aren't suppressed.
+		{`{"title":"a"}`, `{"tags":[],"title":"a"}`, false},
+		{`not json`, `{"title":"a"}`, false},
+	}
+
+	for _, tc := range cases {
+		old := tc.old
+		if json.Valid([]byte(old)) {
+			old = NormalizeDashboardConfigJSON(old)
+		}
+		got := suppressDashboardConfigJSONDiff("config_json", old, NormalizeDashboardConfigJSON(tc.new), nil)
+		if got != tc.suppress {
+			t.Errorf("suppressDashboardConfigJSONDiff(%s, %s) = %t, expected %t", tc.old, tc.new, got, tc.suppress)
+		}
+	}
+}
+
+func TestUpdateDashboard_conflict(t *testing.T) {
+	cases := []struct {
+		body     string
+		expected string
+	}{
+		{`{"message": "The dashboard has been changed by someone else", "status": "version-mismatch"}`, "since Terraform saved version 3"},
+		{`{"message": "A dashboard with the same name in the folder already exists", "status": "name-exists"}`, "has the same title"},
+	}
+
+	for _, tc := range cases {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != "POST" || r.URL.Path != "/api/dashboards/db" {
+				t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				return
+			}
+			var save gapi.NewDashboard
+			if err := json.NewDecoder(r.Body).Decode(&save); err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			if save.Overwrite || save.Model["version"] != float64(3) || save.Model["uid"] != "abc123" {
+				t.Errorf("expected the dashboard to be saved at version 3 without overwriting, got %v", save)
+			}
+			w.WriteHeader(http.StatusPreconditionFailed)
+			w.Write([]byte(tc.body))
+		}))
+
+		c := newTestClient(t, server)
+
+		d := schema.TestResourceDataRaw(t, ResourceDashboard().Schema, map[string]interface{}{
+			"config_json": `{"title": "Dashboard"}`,
+		})
+		d.SetId("abc123")
+		d.Set("uid", "abc123")
+		d.Set("saved_version", 3)
+
+		err := UpdateDashboard(d, c)
+		if err == nil || !strings.Contains(err.Error(), tc.expected) || !strings.Contains(err.Error(), "overwrite") {
+			t.Errorf("expected an error containing %q, got %v", tc.expected, err)
+		}
+		server.Close()
+	}
+}
+
+func TestUpdateDashboard_changedInGrafana(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/dashboards/uid/abc123":
+			// The dashboard was saved in Grafana since Terraform saved
+			// version 3.
+			w.Write([]byte(`{"meta": {"version": 4}, "dashboard": {"id": 7, "uid": "abc123", "version": 4, "title": "Edited in Grafana"}}`))
+		case "POST /api/dashboards/db":
+			var save gapi.NewDashboard
+			if err := json.NewDecoder(r.Body).Decode(&save); err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			if save.Model["version"] != float64(3) {
+				t.Errorf("expected the dashboard to be saved over version 3, got %v", save.Model["version"])
+			}
+			w.WriteHeader(http.StatusPreconditionFailed)
+			w.Write([]byte(`{"message": "The dashboard has been changed by someone else", "status": "version-mismatch"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	d := ResourceDashboard().Data(&terraform.InstanceState{
+		ID: "abc123",
+		Attributes: map[string]string{
+			"uid":           "abc123",
+			"config_json":   `{"title":"Dashboard"}`,
+			"version":       "3",
+			"saved_version": "3",
+		},
+	})
+	if err := ReadDashboard(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Get("version").(int) != 4 || d.Get("saved_version").(int) != 3 {
+		t.Fatalf("expected version 4 to be read and version 3 to be kept as saved, got %d and %d", d.Get("version"), d.Get("saved_version"))
+	}
+
+	d.Set("config_json", `{"title": "Dashboard"}`)
+	if err := UpdateDashboard(d, c); err == nil || !strings.Contains(err.Error(), "since Terraform saved version 3") {
+		t.Fatalf("expected a conflict error, got %v", err)
+	}
+}
+
+func TestCreateDashboard_gnet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/gnet/dashboards/1860/revisions/27/download":
+			w.Write([]byte(`{
+				"__inputs": [
+					{"name": "DS_PROMETHEUS", "type": "datasource", "pluginId": "prometheus"},
+					{"name": "VAR_JOB", "type": "constant", "value": "node"}
+				],
+				"id": null,
+				"gnetId": 1860,
+				"title": "Node Exporter Full"
+			}`))
+		case "POST /api/dashboards/import":
+			var imp gapi.DashboardImport
+			if err := json.NewDecoder(r.Body).Decode(&imp); err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			expected := []gapi.DashboardImportInput{
+				{Name: "DS_PROMETHEUS", Type: "datasource", PluginId: "prometheus", Value: "Prometheus"},
+				{Name: "VAR_JOB", Type: "constant", Value: "node"},
+			}
+			if !reflect.DeepEqual(imp.Inputs, expected) {
+				t.Errorf("expected inputs %v, got %v", expected, imp.Inputs)
+			}
+			if imp.FolderUid != "metrics" || imp.Dashboard["title"] != "Node Exporter Full" {
+				t.Errorf("unexpected import %v", imp)
+			}
+			w.Write([]byte(`{"uid": "abc123", "imported": true}`))
+		case "GET /api/dashboards/uid/abc123":
+			w.Write([]byte(`{
+				"meta": {"slug": "node-exporter-full", "folderUid": "metrics", "version": 1},
+				"dashboard": {"id": 7, "uid": "abc123", "version": 1, "gnetId": 1860, "title": "Node Exporter Full"}
+			}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	d := schema.TestResourceDataRaw(t, ResourceDashboard().Schema, map[string]interface{}{
+		"gnet_id":       1860,
+		"gnet_revision": 27,
+		"folder":        "metrics",
+		"inputs":        map[string]interface{}{"DS_PROMETHEUS": "Prometheus"},
+	})
+
+	if err := CreateDashboard(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Id() != "abc123" {
+		t.Fatalf("expected the dashboard to be identified by its UID, got %q", d.Id())
+	}
+	expected := `{"gnetId":1860,"title":"Node Exporter Full"}`
+	if d.Get("config_json").(string) != expected {
+		t.Fatalf("expected config_json %s, got %s", expected, d.Get("config_json"))
+	}
+}
+
+func TestDashboardImportInputs(t *testing.T) {
+	model := map[string]interface{}{
+		"__inputs": []interface{}{
+			map[string]interface{}{"name": "DS_PROMETHEUS", "type": "datasource", "pluginId": "prometheus"},
+			map[string]interface{}{"name": "DS_LOKI", "type": "datasource", "pluginId": "loki"},
+		},
+	}
+
+	_, err := dashboardImportInputs(model, map[string]interface{}{"DS_PROMETHEUS": "Prometheus"})
+	if err == nil || !strings.Contains(err.Error(), "DS_LOKI") {
+		t.Fatalf("expected an error about the missing input, got %v", err)
+	}
+
+	_, err = dashboardImportInputs(model, map[string]interface{}{"DS_PROMETHEUS": "Prometheus", "DS_LOKI": "Loki", "DS_TEMPO": "Tempo"})
+	if err == nil || !strings.Contains(err.Error(), "DS_TEMPO") {
+		t.Fatalf("expected an error about the unknown input, got %v", err)
+	}
+
+	inputs, err := dashboardImportInputs(map[string]interface{}{}, nil)
+	if err != nil || len(inputs) != 0 {
+		t.Fatalf("expected no inputs, got %v, %v", inputs, err)
+	}
+}
+
 func testAccDashboardCheckExists(rn string, dashboard *gapi.Dashboard) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[rn]
@@ -63,8 +542,8 @@ func testAccDashboardCheckExists(rn string, dashboard *gapi.Dashboard) resource.
 			return fmt.Errorf("resource id not set")
 		}
 
-		client := testAccProvider.Meta().(*gapi.Client)
-		gotDashboard, err := client.Dashboard(rs.Primary.ID)
+		client := testAccProvider.Meta().(*client).gapi
+		gotDashboard, err := client.DashboardByUID(rs.Primary.ID)
 		if err != nil {
 			return fmt.Errorf("error getting dashboard: %s", err)
 		}
@@ -75,20 +554,36 @@ func testAccDashboardCheckExists(rn string, dashboard *gapi.Dashboard) resource.
 	}
 }
 
+// testAccDashboardCheckMessage checks the message of the latest version of
+// the dashboard.
+func testAccDashboardCheckMessage(dashboard *gapi.Dashboard, message string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*client).gapi
+		versions, err := client.DashboardVersions(dashboard.Model["uid"].(string))
+		if err != nil {
+			return fmt.Errorf("error getting dashboard versions: %s", err)
+		}
+		if len(versions) == 0 || versions[0].Message != message {
+			return fmt.Errorf("expected the latest version of the dashboard to have message %q, got %v", message, versions)
+		}
+		return nil
+	}
+}
+
 func testAccDashboardDisappear(dashboard *gapi.Dashboard) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		// At this point testAccDashboardCheckExists should have been called and
 		// dashboard should have been populated
-		client := testAccProvider.Meta().(*gapi.Client)
-		client.DeleteDashboard((*dashboard).Meta.Slug)
+		client := testAccProvider.Meta().(*client).gapi
+		client.DeleteDashboardByUID(dashboard.Model["uid"].(string))
 		return nil
 	}
 }
 
 func testAccDashboardCheckDestroy(dashboard *gapi.Dashboard) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*gapi.Client)
-		_, err := client.Dashboard(dashboard.Meta.Slug)
+		client := testAccProvider.Meta().(*client).gapi
+		_, err := client.DashboardByUID(dashboard.Model["uid"].(string))
 		if err == nil {
 			return fmt.Errorf("dashboard still exists")
 		}
@@ -98,8 +593,7 @@ func testAccDashboardCheckDestroy(dashboard *gapi.Dashboard) resource.TestCheckF
 
 // The "id" and "version" properties in the config below are there to test
 // that we correctly normalize them away. They are not actually used by this
-// resource, since it uses slugs for identification and never modifies an
-// existing dashboard.
+// resource, since it identifies dashboards by their UID.
 const testAccDashboardConfig_basic = `
 resource "grafana_dashboard" "test" {
     config_json = <<EOT
@@ -111,3 +605,16 @@ resource "grafana_dashboard" "test" {
 EOT
 }
 `
+
+const testAccDashboardConfig_update = `
+resource "grafana_dashboard" "test" {
+    message     = "Rename the dashboard"
+    config_json = <<EOT
+{
+    "title": "Terraform Acceptance Test Updated",
+    "id": 12,
+    "version": "43"
+}
+EOT
+}
+`
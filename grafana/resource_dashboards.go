@@ -0,0 +1,266 @@
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"sort"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	gapi "github.com/nytm/go-grafana-api"
+)
+
+// ResourceDashboards manages a set of dashboards in a folder as a single
+// resource, for folders of many dashboards kept as files.
+func ResourceDashboards() *schema.Resource {
+	return &schema.Resource{
+		Create: CreateDashboards,
+		Read:   ReadDashboards,
+		Update: UpdateDashboards,
+		Delete: DeleteDashboards,
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDSchema(),
+
+			"folder": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"dashboards": &schema.Schema{
+				Type:             schema.TypeMap,
+				Required:         true,
+				Elem:             &schema.Schema{Type: schema.TypeString},
+				ValidateFunc:     validateDashboardsConfigJSON,
+				DiffSuppressFunc: suppressDashboardsConfigJSONDiff,
+			},
+
+			"prune": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"uids": &schema.Schema{
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func CreateDashboards(d *schema.ResourceData, meta interface{}) error {
+	d.SetId(resource.UniqueId())
+
+	return UpdateDashboards(d, meta)
+}
+
+func ReadDashboards(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	dashboards := map[string]interface{}{}
+	uids := map[string]interface{}{}
+	for name, uid := range d.Get("uids").(map[string]interface{}) {
+		dashboard, err := client.DashboardByUID(uid.(string))
+		if err != nil {
+			if isNotFound(err) {
+				log.Printf("[WARN] removing dashboard %s (%s) from state because it no longer exists in grafana", name, uid)
+				continue
+			}
+			return accessError(err, fmt.Sprintf("reading dashboard %s (%s)", name, uid))
+		}
+
+		configJSON, err := json.Marshal(dashboard.Model)
+		if err != nil {
+			return err
+		}
+		dashboards[name] = NormalizeDashboardConfigJSON(string(configJSON))
+		uids[name] = uid
+	}
+
+	d.Set("dashboards", dashboards)
+	d.Set("uids", uids)
+
+	return nil
+}
+
+// UpdateDashboards saves the dashboards that were added or changed, deletes
+// the ones that were removed, and prunes the other dashboards in the folder
+// if asked to. Every change is attempted even if others fail, and the
+// dashboards that were actually saved are recorded in state, so that
+// running again only retries the failed changes.
+func UpdateDashboards(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	folder := d.Get("folder").(string)
+	o, n := d.GetChange("dashboards")
+	oldDashboards, newDashboards := o.(map[string]interface{}), n.(map[string]interface{})
+
+	uids := map[string]string{}
+	for name, uid := range d.Get("uids").(map[string]interface{}) {
+		uids[name] = uid.(string)
+	}
+
+	var result *multierror.Error
+	for _, name := range sortedKeys(newDashboards) {
+		configJSON := newDashboards[name].(string)
+		uid, exists := uids[name]
+		old, _ := oldDashboards[name].(string)
+		if exists && !d.HasChange("folder") && suppressDashboardsConfigJSONDiff("", old, configJSON, d) {
+			continue
+		}
+
+		if _, errs := ValidateDashboardConfigJSON(configJSON, fmt.Sprintf("dashboards.%s", name)); len(errs) > 0 {
+			result = multierror.Append(result, errs...)
+			continue
+		}
+
+		model := prepareDashboardModel(configJSON)
+		if exists {
+			model["uid"] = uid
+		}
+		resp, err := client.NewDashboard(gapi.NewDashboard{
+			Model:     model,
+			FolderUid: folder,
+			Overwrite: exists,
+		})
+		if err != nil {
+			result = multierror.Append(result, actionError(err, fmt.Sprintf("saving dashboard %s", name)))
+			continue
+		}
+		uids[name] = resp.Uid
+	}
+
+	for _, name := range sortedKeys(oldDashboards) {
+		if _, ok := newDashboards[name]; ok {
+			continue
+		}
+		uid, ok := uids[name]
+		if !ok {
+			continue
+		}
+		if err := client.DeleteDashboardByUID(uid); err != nil && !isNotFound(err) {
+			result = multierror.Append(result, actionError(err, fmt.Sprintf("deleting dashboard %s (%s)", name, uid)))
+			continue
+		}
+		delete(uids, name)
+	}
+
+	if d.Get("prune").(bool) {
+		if err := pruneDashboards(client, folder, uids); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+
+	state := map[string]interface{}{}
+	for name, uid := range uids {
+		state[name] = uid
+	}
+	d.Set("uids", state)
+
+	if err := result.ErrorOrNil(); err != nil {
+		if readErr := ReadDashboards(d, meta); readErr != nil {
+			log.Printf("[WARN] failed to read the dashboards of %s back: %s", d.Id(), readErr)
+		}
+		return err
+	}
+
+	return ReadDashboards(d, meta)
+}
+
+func DeleteDashboards(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	var result *multierror.Error
+	for name, uid := range d.Get("uids").(map[string]interface{}) {
+		if err := client.DeleteDashboardByUID(uid.(string)); err != nil && !isNotFound(err) {
+			result = multierror.Append(result, actionError(err, fmt.Sprintf("deleting dashboard %s (%s)", name, uid)))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+// pruneDashboards deletes the dashboards in the folder that aren't among
+// the managed ones.
+func pruneDashboards(client *gapi.Client, folder string, uids map[string]string) error {
+	managed := map[string]bool{}
+	for _, uid := range uids {
+		managed[uid] = true
+	}
+
+	params := url.Values{
+		"type": []string{"dash-db"},
+	}
+	if folder == "" {
+		params.Set("folderIds", "0")
+	} else {
+		params.Set("folderUIDs", folder)
+	}
+	results, err := searchAll(client, params)
+	if err != nil {
+		return actionError(err, "searching dashboards to prune")
+	}
+
+	var result *multierror.Error
+	for _, dashboard := range results {
+		if managed[dashboard.Uid] || dashboard.FolderUid != folder {
+			continue
+		}
+		log.Printf("[INFO] pruning dashboard %s (%s)", dashboard.Title, dashboard.Uid)
+		if err := client.DeleteDashboardByUID(dashboard.Uid); err != nil && !isNotFound(err) {
+			result = multierror.Append(result, actionError(err, fmt.Sprintf("pruning dashboard %s (%s)", dashboard.Title, dashboard.Uid)))
+		}
+	}
+	return result.ErrorOrNil()
+}
+
+// validateDashboardsConfigJSON validates each dashboard like the config_json
+// of a grafana_dashboard. Dashboards that aren't known until apply are
+// validated then.
+func validateDashboardsConfigJSON(v interface{}, k string) ([]string, []error) {
+	var errors []error
+	for name, configJSON := range v.(map[string]interface{}) {
+		configJSON, ok := configJSON.(string)
+		if !ok || configJSON == config.UnknownVariableValue {
+			continue
+		}
+		_, errs := ValidateDashboardConfigJSON(configJSON, fmt.Sprintf("%s.%s", k, name))
+		errors = append(errors, errs...)
+	}
+	return nil, errors
+}
+
+// suppressDashboardsConfigJSONDiff compares a dashboard read from Grafana
+// with its configuration in the same way as the config_json of a
+// grafana_dashboard, which is normalized before it's compared. The count of
+// dashboards is compared as is.
+func suppressDashboardsConfigJSONDiff(k, old, new string, d *schema.ResourceData) bool {
+	if !json.Valid([]byte(new)) {
+		return false
+	}
+	return suppressDashboardConfigJSONDiff(k, old, NormalizeDashboardConfigJSON(new), d)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,193 @@
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	gapi "github.com/nytm/go-grafana-api"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccDashboards_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccDashboardsCheckDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccDashboardsConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_dashboards.test", "dashboards.%", "2"),
+					resource.TestCheckResourceAttr("grafana_dashboards.test", "uids.%", "2"),
+					testAccDashboardsCheckExist("grafana_dashboards.test"),
+				),
+			},
+			// Dashboards removed from the map are deleted, changed ones are
+			// updated in place.
+			resource.TestStep{
+				Config: testAccDashboardsConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_dashboards.test", "dashboards.%", "1"),
+					resource.TestCheckResourceAttr("grafana_dashboards.test", "uids.%", "1"),
+					testAccDashboardsCheckExist("grafana_dashboards.test"),
+				),
+			},
+		},
+	})
+}
+
+func TestCreateDashboards(t *testing.T) {
+	var mu sync.Mutex
+	saved := map[string]map[string]interface{}{}
+	var deleted []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/dashboards/db":
+			var save gapi.NewDashboard
+			if err := json.NewDecoder(r.Body).Decode(&save); err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			if save.FolderUid != "team" || save.Overwrite {
+				t.Errorf("unexpected save %v", save)
+			}
+			uid := fmt.Sprintf("uid-%d", len(saved)+1)
+			save.Model["uid"] = uid
+			saved[uid] = save.Model
+			fmt.Fprintf(w, `{"uid": %q}`, uid)
+		case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/api/dashboards/uid/"):
+			model, ok := saved[strings.TrimPrefix(r.URL.Path, "/api/dashboards/uid/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			json.NewEncoder(w).Encode(gapi.Dashboard{Model: model})
+		case r.Method == "GET" && r.URL.Path == "/api/search":
+			if r.URL.Query().Get("folderUIDs") != "team" {
+				t.Errorf("unexpected search %s", r.URL)
+			}
+			w.Write([]byte(`[
+				{"uid": "uid-1", "title": "Requests", "folderUid": "team"},
+				{"uid": "uid-2", "title": "Errors", "folderUid": "team"},
+				{"uid": "by-hand", "title": "By Hand", "folderUid": "team"}
+			]`))
+		case r.Method == "DELETE" && strings.HasPrefix(r.URL.Path, "/api/dashboards/uid/"):
+			deleted = append(deleted, strings.TrimPrefix(r.URL.Path, "/api/dashboards/uid/"))
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	d := schema.TestResourceDataRaw(t, ResourceDashboards().Schema, map[string]interface{}{
+		"folder": "team",
+		"prune":  true,
+		"dashboards": map[string]interface{}{
+			"errors.json":   `{"title": "Errors"}`,
+			"requests.json": `{"title": "Requests", "id": 3}`,
+		},
+	})
+
+	if err := CreateDashboards(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expectedUIDs := map[string]interface{}{"errors.json": "uid-1", "requests.json": "uid-2"}
+	if uids := d.Get("uids").(map[string]interface{}); !reflect.DeepEqual(uids, expectedUIDs) {
+		t.Fatalf("expected uids %v, got %v", expectedUIDs, uids)
+	}
+	expectedDashboards := map[string]interface{}{
+		"errors.json":   `{"title":"Errors"}`,
+		"requests.json": `{"title":"Requests"}`,
+	}
+	if dashboards := d.Get("dashboards").(map[string]interface{}); !reflect.DeepEqual(dashboards, expectedDashboards) {
+		t.Fatalf("expected dashboards %v, got %v", expectedDashboards, dashboards)
+	}
+	if !reflect.DeepEqual(deleted, []string{"by-hand"}) {
+		t.Fatalf("expected only the unmanaged dashboard to be pruned, got %v", deleted)
+	}
+}
+
+func testAccDashboardsCheckExist(rn string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		client := testAccProvider.Meta().(*client).gapi
+		for key, uid := range rs.Primary.Attributes {
+			if !strings.HasPrefix(key, "uids.") || key == "uids.%" {
+				continue
+			}
+			if _, err := client.DashboardByUID(uid); err != nil {
+				return fmt.Errorf("error getting dashboard %s: %s", key, err)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccDashboardsCheckDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*client).gapi
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "grafana_dashboards" {
+			continue
+		}
+		for key, uid := range rs.Primary.Attributes {
+			if !strings.HasPrefix(key, "uids.") || key == "uids.%" {
+				continue
+			}
+			if _, err := client.DashboardByUID(uid); err == nil {
+				return fmt.Errorf("dashboard %s still exists", key)
+			}
+		}
+	}
+	return nil
+}
+
+const testAccDashboardsConfig_basic = `
+resource "grafana_folder" "test" {
+    title = "Terraform Acceptance Test Dashboards"
+}
+
+resource "grafana_dashboards" "test" {
+    folder = "${grafana_folder.test.uid}"
+
+    dashboards = {
+        requests = "{\"title\": \"Terraform Acceptance Test Requests\"}"
+        errors   = "{\"title\": \"Terraform Acceptance Test Errors\"}"
+    }
+}
+`
+
+const testAccDashboardsConfig_update = `
+resource "grafana_folder" "test" {
+    title = "Terraform Acceptance Test Dashboards"
+}
+
+resource "grafana_dashboards" "test" {
+    folder = "${grafana_folder.test.uid}"
+
+    dashboards = {
+        requests = "{\"title\": \"Terraform Acceptance Test Requests\", \"tags\": [\"updated\"]}"
+    }
+}
+`
@@ -1,10 +1,15 @@
 package grafana
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
+	"sort"
 	"strconv"
+	"strings"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform/helper/schema"
 
 	gapi "github.com/nytm/go-grafana-api"
@@ -16,16 +21,29 @@ func ResourceDataSource() *schema.Resource {
 		Update: UpdateDataSource,
 		Delete: DeleteDataSource,
 		Read:   ReadDataSource,
+		Importer: &schema.ResourceImporter{
+			State: ImportDataSource,
+		},
 
 		Schema: map[string]*schema.Schema{
+			"org_id": orgIDSchema(),
+
 			"id": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
 			},
 
+			"uid": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateUID,
+			},
+
 			"type": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 
 			"name": &schema.Schema{
@@ -77,17 +95,19 @@ func ResourceDataSource() *schema.Resource {
 			},
 
 			"json_data": &schema.Schema{
-				Type:     schema.TypeList,
-				Optional: true,
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"json_data_encoded"},
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"auth_type": &schema.Schema{
 							Type:     schema.TypeString,
-							Required: true,
+							Optional: true,
 						},
 						"default_region": &schema.Schema{
 							Type:     schema.TypeString,
-							Required: true,
+							Optional: true,
 						},
 						"custom_metrics_namespaces": &schema.Schema{
 							Type:     schema.TypeString,
@@ -97,28 +117,153 @@ func ResourceDataSource() *schema.Resource {
 							Type:     schema.TypeString,
 							Optional: true,
 						},
+						"external_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"profile": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"tls_skip_verify": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"tls_auth": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"tls_auth_with_ca_cert": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"time_interval": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"http_method": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateStringIn("GET", "POST"),
+						},
+						"query_timeout": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"es_version": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"time_field": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"interval": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateStringIn("Hourly", "Daily", "Weekly", "Monthly", "Yearly"),
+						},
+						"max_concurrent_shard_requests": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validateNonNegative,
+						},
+						"ssl_mode": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateStringIn("disable", "require", "verify-ca", "verify-full"),
+						},
+						"postgres_version": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validateNonNegative,
+						},
+						"timescaledb": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"max_open_conns": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validateNonNegative,
+						},
+						"max_idle_conns": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validateNonNegative,
+						},
+						"conn_max_lifetime": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validateNonNegative,
+						},
 					},
 				},
 			},
 
+			"azure_auth": azureAuthSchema(),
+
+			"google_auth": googleAuthSchema(),
+
+			"derived_field": derivedFieldSchema(),
+
+			"exemplar_destination": exemplarDestinationSchema(),
+
+			"http_header": httpHeaderSchema(),
+
+			"json_data_encoded": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"json_data"},
+				StateFunc:     normalizeDataSourceJSON,
+				ValidateFunc:  validateDataSourceJSON,
+			},
+
 			"secure_json_data": &schema.Schema{
-				Type:      schema.TypeList,
-				Optional:  true,
-				Sensitive: true,
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				Sensitive:     true,
+				ConflictsWith: []string{"secure_json_data_encoded"},
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"access_key": &schema.Schema{
-							Type:     schema.TypeString,
-							Required: true,
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
 						},
 						"secret_key": &schema.Schema{
-							Type:     schema.TypeString,
-							Required: true,
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
+						},
+						"tls_ca_cert": &schema.Schema{
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
+						},
+						"tls_client_cert": &schema.Schema{
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
+						},
+						"tls_client_key": &schema.Schema{
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
 						},
 					},
 				},
 			},
 
+			"secure_json_data_encoded": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				StateFunc:    normalizeDataSourceJSON,
+				ValidateFunc: validateDataSourceSecureJSON,
+			},
+
 			"database_name": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
@@ -126,9 +271,16 @@ func ResourceDataSource() *schema.Resource {
 			},
 
 			"access_mode": &schema.Schema{
-				Type:     schema.TypeString,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "proxy",
+				ValidateFunc: validateStringIn("proxy", "direct"),
+			},
+
+			"verify": &schema.Schema{
+				Type:     schema.TypeBool,
 				Optional: true,
-				Default:  "proxy",
+				Default:  false,
 			},
 		},
 	}
@@ -136,75 +288,499 @@ func ResourceDataSource() *schema.Resource {
 
 // CreateDataSource creates a Grafana datasource
 func CreateDataSource(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*gapi.Client)
+	c := meta.(*client)
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
 
 	dataSource, err := makeDataSource(d)
 	if err != nil {
 		return err
 	}
 
+	if err := checkDataSource(d, meta); err != nil {
+		return err
+	}
+
 	id, err := client.NewDataSource(dataSource)
 	if err != nil {
-		return err
+		// A data source that couldn't be created isn't the default.
+		c.releaseDefaultDataSource(int64(d.Get("org_id").(int)), dataSource.Name)
+		if statusCode(err) == http.StatusConflict {
+			return fmt.Errorf("Error creating data source %q: a data source with this name or uid already exists (e.g. when replacing with create_before_destroy, give the new one another name and uid): %s", dataSource.Name, err)
+		}
+		return accessError(err, "creating data source")
 	}
 
 	d.SetId(strconv.FormatInt(id, 10))
 
+	if d.Get("verify").(bool) {
+		if err := verifyDataSource(client, id, dataSource.Name); err != nil {
+			return err
+		}
+	}
+
 	return ReadDataSource(d, meta)
 }
 
 // UpdateDataSource updates a Grafana datasource
 func UpdateDataSource(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*gapi.Client)
+	if err := checkDataSource(d, meta); err != nil {
+		return err
+	}
+
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
 
 	dataSource, err := makeDataSource(d)
 	if err != nil {
 		return err
 	}
 
-	return client.UpdateDataSource(dataSource)
+	err = client.UpdateDataSource(dataSource)
+	if err != nil {
+		return accessError(err, fmt.Sprintf("updating data source %s", d.Id()))
+	}
+
+	if d.Get("verify").(bool) {
+		if err := verifyDataSource(client, dataSource.Id, dataSource.Name); err != nil {
+			return err
+		}
+	}
+
+	return ReadDataSource(d, meta)
+}
+
+// checkDataSource checks what can be before a data source is saved: that no
+// other data source of its organization is made the default, and that
+// Grafana can verify it if asked to.
+func checkDataSource(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*client)
+	orgID := int64(d.Get("org_id").(int))
+	if oldName, newName := d.GetChange("name"); oldName != newName {
+		c.releaseDefaultDataSource(orgID, oldName.(string))
+	}
+	if d.Get("is_default").(bool) {
+		if err := c.claimDefaultDataSource(orgID, d.Get("name").(string)); err != nil {
+			return err
+		}
+	} else {
+		c.releaseDefaultDataSource(orgID, d.Get("name").(string))
+	}
+	if d.Get("verify").(bool) {
+		if err := c.requireVersion("verify", "7.0.0"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyDataSource runs the health check of a data source once it's saved.
+// Data source types without a health check are assumed to be healthy.
+func verifyDataSource(client *gapi.Client, id int64, name string) error {
+	message, err := client.CheckDataSourceHealth(id)
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] data source %s can't be verified: its type has no health check", name)
+			return nil
+		}
+		if statusCode(err) == http.StatusBadRequest {
+			return fmt.Errorf("Data source %s failed its health check: %s", name, err)
+		}
+		return accessError(err, fmt.Sprintf("checking the health of data source %s", name))
+	}
+	log.Printf("[DEBUG] data source %s is healthy: %s", name, message)
+	return nil
 }
 
 // ReadDataSource reads a Grafana datasource
 func ReadDataSource(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*gapi.Client)
+	c := meta.(*client)
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
 
 	idStr := d.Id()
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		if err.Error() == "404 Not Found" {
-			log.Printf("[WARN] removing datasource %s from state because it no longer exists in grafana", d.Get("name").(string))
-			d.SetId("")
-			return nil
-		}
 		return fmt.Errorf("Invalid id: %#v", idStr)
 	}
 
 	dataSource, err := client.DataSource(id)
 	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] removing datasource %s from state because it no longer exists in grafana", d.Get("name").(string))
+			d.SetId("")
+			return nil
+		}
+		return accessError(err, fmt.Sprintf("reading data source %s", idStr))
+	}
 
-		return err
+	// Data sources that were last made the default by Terraform keep their
+	// claim on it, even when it was given to another data source in Grafana
+	// since, which then shows up as a change to take it back.
+	if d.Get("is_default").(bool) {
+		if err := c.claimDefaultDataSource(int64(d.Get("org_id").(int)), d.Get("name").(string)); err != nil {
+			return err
+		}
 	}
 
 	d.Set("id", dataSource.Id)
+	d.Set("uid", dataSource.Uid)
 	d.Set("access_mode", dataSource.Access)
 	d.Set("basic_auth_enabled", dataSource.BasicAuth)
 	d.Set("basic_auth_username", dataSource.BasicAuthUser)
-	d.Set("basic_auth_password", dataSource.BasicAuthPassword)
 	d.Set("database_name", dataSource.Database)
 	d.Set("is_default", dataSource.IsDefault)
 	d.Set("name", dataSource.Name)
-	d.Set("password", dataSource.Password)
 	d.Set("type", dataSource.Type)
 	d.Set("url", dataSource.URL)
 	d.Set("username", dataSource.User)
 
+	if d.Get("json_data_encoded").(string) != "" {
+		jsonData, err := json.Marshal(dataSource.JSONData)
+		if err != nil {
+			return err
+		}
+		d.Set("json_data_encoded", string(jsonData))
+	} else {
+		if err := d.Set("json_data", readJSONData(dataSource.JSONData)); err != nil {
+			return err
+		}
+		readCloudAuth(d, dataSource)
+		readTraceLinks(d, dataSource)
+		readHTTPHeaders(d, dataSource)
+	}
+	readDataSourceSecrets(d, dataSource)
+
 	return nil
 }
 
+// dataSourceJSONDataField is a typed field of json_data, along with the key
+// Grafana stores it under and the data source types it applies to. Fields
+// without types apply to data sources of any type.
+type dataSourceJSONDataField struct {
+	key   string
+	types []string
+}
+
+var dataSourceJSONDataFields = map[string]dataSourceJSONDataField{
+	"auth_type":                     {"authType", []string{"cloudwatch"}},
+	"default_region":                {"defaultRegion", []string{"cloudwatch"}},
+	"custom_metrics_namespaces":     {"customMetricsNamespaces", []string{"cloudwatch"}},
+	"assume_role_arn":               {"assumeRoleArn", []string{"cloudwatch"}},
+	"external_id":                   {"externalId", []string{"cloudwatch"}},
+	"profile":                       {"profile", []string{"cloudwatch"}},
+	"tls_skip_verify":               {"tlsSkipVerify", nil},
+	"tls_auth":                      {"tlsAuth", nil},
+	"tls_auth_with_ca_cert":         {"tlsAuthWithCACert", nil},
+	"time_interval":                 {"timeInterval", nil},
+	"http_method":                   {"httpMethod", []string{"prometheus", "influxdb"}},
+	"query_timeout":                 {"queryTimeout", []string{"prometheus"}},
+	"es_version":                    {"esVersion", []string{"elasticsearch"}},
+	"time_field":                    {"timeField", []string{"elasticsearch"}},
+	"interval":                      {"interval", []string{"elasticsearch"}},
+	"max_concurrent_shard_requests": {"maxConcurrentShardRequests", []string{"elasticsearch"}},
+	"ssl_mode":                      {"sslmode", []string{"postgres"}},
+	"postgres_version":              {"postgresVersion", []string{"postgres"}},
+	"timescaledb":                   {"timescaledb", []string{"postgres"}},
+	"max_open_conns":                {"maxOpenConns", []string{"postgres", "mysql", "mssql"}},
+	"max_idle_conns":                {"maxIdleConns", []string{"postgres", "mysql", "mssql"}},
+	"conn_max_lifetime":             {"connMaxLifetime", []string{"postgres", "mysql", "mssql"}},
+}
+
+// dataSourceRequiredFields are the attributes that data sources of some
+// types can't work without.
+var dataSourceRequiredFields = map[string][]string{
+	"cloudwatch":    {"json_data.0.auth_type", "json_data.0.default_region"},
+	"elasticsearch": {"database_name"},
+	"mysql":         {"database_name"},
+	"postgres":      {"database_name"},
+	"mssql":         {"database_name"},
+}
+
+// validateDataSourceType checks that the attributes a data source of its
+// type requires are set, and that the typed fields of json_data apply to its
+// type. Types the provider doesn't know about, such as plugins, accept any
+// field. The required fields may also be given in json_data_encoded. This
+// can only be checked once the type is known, when applying.
+func validateDataSourceType(d *schema.ResourceData) error {
+	dataSourceType := d.Get("type").(string)
+
+	jsonData := makeJSONData(d)
+
+	var result *multierror.Error
+	for _, attribute := range dataSourceRequiredFields[dataSourceType] {
+		name := strings.TrimPrefix(attribute, "json_data.0.")
+		if name != attribute {
+			if isEmptyValue(jsonData[dataSourceJSONDataFields[name].key]) {
+				result = multierror.Append(result, fmt.Errorf("%s is required for data sources of type %s", attribute, dataSourceType))
+			}
+		} else if d.Get(attribute).(string) == "" {
+			result = multierror.Append(result, fmt.Errorf("%s is required for data sources of type %s", attribute, dataSourceType))
+		}
+	}
+
+	known := false
+	for _, field := range dataSourceJSONDataFields {
+		for _, t := range field.types {
+			known = known || t == dataSourceType
+		}
+	}
+	result = multierror.Append(result, validateCloudAuth(d)...)
+	result = multierror.Append(result, validateTraceLinks(d)...)
+	if !known {
+		return result.ErrorOrNil()
+	}
+
+	for _, name := range sortedFieldNames() {
+		field := dataSourceJSONDataFields[name]
+		if field.types == nil || isEmptyValue(d.Get("json_data.0."+name)) {
+			continue
+		}
+		applies := false
+		for _, t := range field.types {
+			applies = applies || t == dataSourceType
+		}
+		if !applies {
+			result = multierror.Append(result, fmt.Errorf("json_data.0.%s doesn't apply to data sources of type %s, only %s", name, dataSourceType, strings.Join(field.types, ", ")))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+func sortedFieldNames() []string {
+	names := make([]string, 0, len(dataSourceJSONDataFields))
+	for name := range dataSourceJSONDataFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func isEmptyValue(v interface{}) bool {
+	switch v := v.(type) {
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	case int:
+		return v == 0
+	}
+	return v == nil
+}
+
+// readJSONData returns the json_data block of the typed fields Grafana
+// returned, leaving out the keys that have no typed field. Values are
+// converted to the type of their field, since data sources saved outside of
+// Terraform may store e.g. numbers as strings or the other way around; values
+// that can't be converted are left out.
+func readJSONData(jsonData map[string]interface{}) []interface{} {
+	fields := ResourceDataSource().Schema["json_data"].Elem.(*schema.Resource).Schema
+
+	block := map[string]interface{}{}
+	for name, field := range dataSourceJSONDataFields {
+		value, ok := jsonData[field.key]
+		if !ok {
+			continue
+		}
+		if value, ok := convertJSONDataValue(value, fields[name].Type); ok {
+			block[name] = value
+		} else {
+			log.Printf("[WARN] ignoring %s of data source, %#v isn't a valid %s", field.key, jsonData[field.key], fields[name].Type)
+		}
+	}
+	if len(block) == 0 {
+		return nil
+	}
+	return []interface{}{block}
+}
+
+// convertJSONDataValue converts a value decoded from JSON, where numbers are
+// floats, to the given schema type.
+func convertJSONDataValue(value interface{}, valueType schema.ValueType) (interface{}, bool) {
+	switch valueType {
+	case schema.TypeString:
+		switch v := value.(type) {
+		case string:
+			return v, true
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), true
+		case bool:
+			return strconv.FormatBool(v), true
+		}
+	case schema.TypeBool:
+		switch v := value.(type) {
+		case bool:
+			return v, true
+		case string:
+			b, err := strconv.ParseBool(v)
+			return b, err == nil
+		}
+	case schema.TypeInt:
+		switch v := value.(type) {
+		case float64:
+			return int(v), v == float64(int(v))
+		case string:
+			i, err := strconv.Atoi(v)
+			return i, err == nil
+		}
+	}
+	return nil, false
+}
+
+// dataSourceSecrets are the keys of secureJsonData that Grafana stores the
+// secret attributes of data sources under.
+var dataSourceSecrets = map[string]string{
+	"password":                           "password",
+	"basic_auth_password":                "basicAuthPassword",
+	"secure_json_data.0.access_key":      "accessKey",
+	"secure_json_data.0.secret_key":      "secretKey",
+	"secure_json_data.0.tls_ca_cert":     "tlsCACert",
+	"secure_json_data.0.tls_client_cert": "tlsClientCert",
+	"secure_json_data.0.tls_client_key":  "tlsClientKey",
+	"azure_auth.0.client_secret":         "clientSecret",
+}
+
+// readDataSourceSecrets keeps the secrets of a data source in state as they
+// were last set, since Grafana never returns them, only which of them are
+// set. Secrets that Grafana no longer has, e.g. because they were reset in
+// its web UI, are cleared, so that they show up as changes to set them again.
+// Grafana servers too old to report which secrets are set return the
+// password and basic auth password as plain attributes instead.
+func readDataSourceSecrets(d *schema.ResourceData, dataSource *gapi.DataSource) {
+	if dataSource.SecureJSONFields == nil {
+		if dataSource.Password != "" {
+			d.Set("password", dataSource.Password)
+		}
+		if dataSource.BasicAuthPassword != "" {
+			d.Set("basic_auth_password", dataSource.BasicAuthPassword)
+		}
+		return
+	}
+
+	if !dataSource.SecureJSONFields["password"] && dataSource.Password == "" {
+		d.Set("password", "")
+	}
+	if !dataSource.SecureJSONFields["basicAuthPassword"] && dataSource.BasicAuthPassword == "" {
+		d.Set("basic_auth_password", "")
+	}
+
+	readSecureJSONDataEncoded(d, dataSource)
+
+	blocks := map[string]map[string]interface{}{}
+	for attribute, key := range dataSourceSecrets {
+		parts := strings.SplitN(attribute, ".0.", 2)
+		if len(parts) != 2 || dataSource.SecureJSONFields[key] {
+			continue
+		}
+		block, ok := blocks[parts[0]]
+		if !ok {
+			list := d.Get(parts[0]).([]interface{})
+			if len(list) == 0 || list[0] == nil {
+				continue
+			}
+			block = list[0].(map[string]interface{})
+			blocks[parts[0]] = block
+		}
+		block[parts[1]] = ""
+	}
+	for name, block := range blocks {
+		d.Set(name, []interface{}{block})
+	}
+}
+
+// readSecureJSONDataEncoded removes the secrets that Grafana no longer has
+// from secure_json_data_encoded.
+func readSecureJSONDataEncoded(d *schema.ResourceData, dataSource *gapi.DataSource) {
+	encoded := d.Get("secure_json_data_encoded").(string)
+	if encoded == "" || dataSource.SecureJSONFields == nil {
+		return
+	}
+	secrets := map[string]string{}
+	if err := json.Unmarshal([]byte(encoded), &secrets); err != nil {
+		return
+	}
+	for key := range secrets {
+		if !dataSource.SecureJSONFields[key] {
+			delete(secrets, key)
+		}
+	}
+	d.Set("secure_json_data_encoded", normalizeDataSourceJSON(secrets))
+}
+
+func validateDataSourceJSON(v interface{}, k string) ([]string, []error) {
+	jsonData := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(v.(string)), &jsonData); err != nil {
+		return nil, []error{fmt.Errorf("%s must be a JSON object: %s", k, err)}
+	}
+	return nil, nil
+}
+
+func validateDataSourceSecureJSON(v interface{}, k string) ([]string, []error) {
+	secrets := map[string]string{}
+	if err := json.Unmarshal([]byte(v.(string)), &secrets); err != nil {
+		return nil, []error{fmt.Errorf("%s must be a JSON object of strings: %s", k, err)}
+	}
+	return nil, nil
+}
+
+// normalizeDataSourceJSON sorts the keys of a JSON object, so that it can be
+// compared with the one Grafana returns. It's also given decoded objects.
+func normalizeDataSourceJSON(v interface{}) string {
+	if encoded, ok := v.(string); ok {
+		decoded := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(encoded), &decoded); err != nil {
+			// The validate function should've taken care of this.
+			return encoded
+		}
+		v = decoded
+	}
+
+	ret, err := json.Marshal(v)
+	if err != nil {
+		// Should never happen.
+		return ""
+	}
+
+	return string(ret)
+}
+
+// ImportDataSource imports a Grafana datasource by its ID or UID.
+func ImportDataSource(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, err := strconv.ParseInt(d.Id(), 10, 64); err == nil {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	dataSource, err := client.DataSourceByUID(d.Id())
+	if err != nil {
+		if isNotFound(err) {
+			return nil, fmt.Errorf("Data source %q not found", d.Id())
+		}
+		return nil, accessError(err, fmt.Sprintf("importing data source %s", d.Id()))
+	}
+
+	d.SetId(strconv.FormatInt(dataSource.Id, 10))
+
+	return []*schema.ResourceData{d}, nil
+}
+
 // DeleteDataSource deletes a Grafana datasource
 func DeleteDataSource(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*gapi.Client)
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
 
 	idStr := d.Id()
 	id, err := strconv.ParseInt(idStr, 10, 64)
@@ -212,7 +788,21 @@ func DeleteDataSource(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Invalid id: %#v", idStr)
 	}
 
-	return client.DeleteDataSource(id)
+	// A data source replaced with create_before_destroy has handed the
+	// default over to its replacement by now. Otherwise the organization is
+	// left without a default, which the provider can't prevent.
+	if d.Get("is_default").(bool) {
+		if dataSource, err := client.DataSource(id); err == nil && dataSource.IsDefault {
+			log.Printf("[WARN] deleting data source %s, the default data source of its organization: dashboards using the default data source are broken until another one is made the default (set create_before_destroy when replacing it)", idStr)
+		}
+	}
+
+	err = client.DeleteDataSource(id)
+	if err != nil && !isNotFound(err) {
+		return accessError(err, fmt.Sprintf("deleting data source %s", idStr))
+	}
+
+	return nil
 }
 
 func makeDataSource(d *schema.ResourceData) (*gapi.DataSource, error) {
@@ -223,8 +813,18 @@ func makeDataSource(d *schema.ResourceData) (*gapi.DataSource, error) {
 		id, err = strconv.ParseInt(idStr, 10, 64)
 	}
 
+	if err == nil {
+		err = validateDataSourceType(d)
+	}
+
+	jsonData, secureJSONData := makeJSONData(d), makeSecureJSONData(d)
+	makeCloudAuth(d, jsonData, secureJSONData)
+	makeTraceLinks(d, jsonData)
+	makeHTTPHeaders(d, jsonData, secureJSONData)
+
 	return &gapi.DataSource{
 		Id:                id,
+		Uid:               d.Get("uid").(string),
 		Name:              d.Get("name").(string),
 		Type:              d.Get("type").(string),
 		URL:               d.Get("url").(string),
@@ -236,23 +836,42 @@ func makeDataSource(d *schema.ResourceData) (*gapi.DataSource, error) {
 		BasicAuth:         d.Get("basic_auth_enabled").(bool),
 		BasicAuthUser:     d.Get("basic_auth_username").(string),
 		BasicAuthPassword: d.Get("basic_auth_password").(string),
-		JSONData:          makeJSONData(d),
-		SecureJSONData:    makeSecureJSONData(d),
+		JSONData:          jsonData,
+		SecureJSONData:    secureJSONData,
 	}, err
 }
 
-func makeJSONData(d *schema.ResourceData) gapi.JSONData {
-	return gapi.JSONData{
-		AuthType:                d.Get("json_data.0.auth_type").(string),
-		DefaultRegion:           d.Get("json_data.0.default_region").(string),
-		CustomMetricsNamespaces: d.Get("json_data.0.custom_metrics_namespaces").(string),
-		AssumeRoleArn:           d.Get("json_data.0.assume_role_arn").(string),
+func makeJSONData(d *schema.ResourceData) map[string]interface{} {
+	jsonData := map[string]interface{}{}
+	if encoded := d.Get("json_data_encoded").(string); encoded != "" {
+		// The validate function takes care of invalid JSON.
+		json.Unmarshal([]byte(encoded), &jsonData)
+		return jsonData
+	}
+	for name, field := range dataSourceJSONDataFields {
+		if value := d.Get("json_data.0." + name); !isEmptyValue(value) {
+			jsonData[field.key] = value
+		}
 	}
+	return jsonData
 }
 
-func makeSecureJSONData(d *schema.ResourceData) gapi.SecureJSONData {
-	return gapi.SecureJSONData{
-		AccessKey: d.Get("secure_json_data.0.access_key").(string),
-		SecretKey: d.Get("secure_json_data.0.secret_key").(string),
+// makeSecureJSONData returns the secrets to set. Secrets that aren't set are
+// left out, which leaves them unchanged in Grafana, unless they were removed
+// from the configuration since it was last applied: those are set to an
+// empty string, which clears them. The passwords are also given as plain
+// attributes, for Grafana servers older than 8.
+func makeSecureJSONData(d *schema.ResourceData) map[string]string {
+	oldEncoded, newEncoded := d.GetChange("secure_json_data_encoded")
+	secureJSONData := removedDataSourceSecrets(oldEncoded.(string), newEncoded.(string))
+	for key, value := range decodeDataSourceSecrets(newEncoded.(string)) {
+		secureJSONData[key] = value
+	}
+	for attribute, key := range dataSourceSecrets {
+		oldValue, newValue := d.GetChange(attribute)
+		if newValue.(string) != "" || oldValue.(string) != "" {
+			secureJSONData[key] = newValue.(string)
+		}
 	}
+	return secureJSONData
 }
@@ -0,0 +1,132 @@
+package grafana
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	gapi "github.com/nytm/go-grafana-api"
+)
+
+func ResourceDataSourceCaching() *schema.Resource {
+	return &schema.Resource{
+		Create: UpdateDataSourceCaching,
+		Read:   ReadDataSourceCaching,
+		Update: UpdateDataSourceCaching,
+		Delete: DeleteDataSourceCaching,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDSchema(),
+
+			"data_source_uid": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"use_default_ttl": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"ttl_queries_ms": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateNonNegative,
+			},
+
+			"ttl_resources_ms": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateNonNegative,
+			},
+		},
+	}
+}
+
+func UpdateDataSourceCaching(d *schema.ResourceData, meta interface{}) error {
+	if err := meta.(*client).requireEnterprise("grafana_data_source_caching"); err != nil {
+		return err
+	}
+	if err := meta.(*client).requireVersion("grafana_data_source_caching", "9.0.0"); err != nil {
+		return err
+	}
+
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	uid := d.Get("data_source_uid").(string)
+	err = client.UpdateDataSourceCache(uid, &gapi.DataSourceCache{
+		DataSourceUID:  uid,
+		Enabled:        d.Get("enabled").(bool),
+		UseDefaultTTL:  d.Get("use_default_ttl").(bool),
+		TTLQueriesMs:   int64(d.Get("ttl_queries_ms").(int)),
+		TTLResourcesMs: int64(d.Get("ttl_resources_ms").(int)),
+	})
+	if err != nil {
+		return accessError(err, fmt.Sprintf("updating query caching of data source %s", uid))
+	}
+
+	d.SetId(uid)
+
+	return ReadDataSourceCaching(d, meta)
+}
+
+func ReadDataSourceCaching(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	cache, err := client.DataSourceCache(d.Id())
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] removing query caching of data source %s from state because the data source no longer exists in grafana", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return accessError(err, fmt.Sprintf("reading query caching of data source %s", d.Id()))
+	}
+
+	d.Set("data_source_uid", d.Id())
+	d.Set("enabled", cache.Enabled)
+	d.Set("use_default_ttl", cache.UseDefaultTTL)
+	d.Set("ttl_queries_ms", int(cache.TTLQueriesMs))
+	d.Set("ttl_resources_ms", int(cache.TTLResourcesMs))
+
+	return nil
+}
+
+// DeleteDataSourceCaching disables query caching of the data source, and
+// resets it to the default TTLs.
+func DeleteDataSourceCaching(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	err = client.UpdateDataSourceCache(d.Id(), &gapi.DataSourceCache{
+		DataSourceUID: d.Id(),
+		Enabled:       false,
+		UseDefaultTTL: true,
+	})
+	if err != nil && !isNotFound(err) {
+		return accessError(err, fmt.Sprintf("disabling query caching of data source %s", d.Id()))
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package grafana
+
+import (
+	"fmt"
+	"testing"
+
+	gapi "github.com/nytm/go-grafana-api"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccDataSourceCaching_basic(t *testing.T) {
+	var dataSource gapi.DataSource
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckEnterprise(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccDataSourceCheckDestroy(&dataSource),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccDataSourceCachingConfig(true, 60000),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceCheckExists("grafana_data_source.test", &dataSource),
+					testAccDataSourceCachingCheckEnabled("grafana_data_source_caching.test", true),
+					resource.TestCheckResourceAttr(
+						"grafana_data_source_caching.test", "ttl_queries_ms", "60000",
+					),
+				),
+			},
+			resource.TestStep{
+				Config: testAccDataSourceCachingConfig(false, 120000),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceCachingCheckEnabled("grafana_data_source_caching.test", false),
+					resource.TestCheckResourceAttr(
+						"grafana_data_source_caching.test", "ttl_queries_ms", "120000",
+					),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "grafana_data_source_caching.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccDataSourceCachingCheckEnabled(rn string, enabled bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		client := testAccProvider.Meta().(*client).gapi
+		cache, err := client.DataSourceCache(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error getting query caching: %s", err)
+		}
+		if cache.Enabled != enabled {
+			return fmt.Errorf("expected query caching enabled to be %t, got %t", enabled, cache.Enabled)
+		}
+
+		return nil
+	}
+}
+
+func testAccDataSourceCachingConfig(enabled bool, ttl int) string {
+	return fmt.Sprintf(`
+resource "grafana_data_source" "test" {
+    uid  = "tf-acc-test-ds-caching"
+    type = "prometheus"
+    name = "terraform-acc-test-ds-caching"
+    url  = "http://terraform-acc-test.invalid/"
+}
+
+resource "grafana_data_source_caching" "test" {
+    data_source_uid  = "${grafana_data_source.test.uid}"
+    enabled          = %t
+    ttl_queries_ms   = %d
+    ttl_resources_ms = 300000
+}
+`, enabled, ttl)
+}
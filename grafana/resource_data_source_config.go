@@ -0,0 +1,189 @@
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// ResourceDataSourceConfig manages the json_data and secrets of a data source
+// that is created elsewhere, e.g. by another team or another configuration.
+func ResourceDataSourceConfig() *schema.Resource {
+	return &schema.Resource{
+		Create: UpdateDataSourceConfig,
+		Read:   ReadDataSourceConfig,
+		Update: UpdateDataSourceConfig,
+		Delete: DeleteDataSourceConfig,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDSchema(),
+
+			"data_source_uid": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateUID,
+			},
+
+			"json_data_encoded": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				StateFunc:    normalizeDataSourceJSON,
+				ValidateFunc: validateDataSourceJSON,
+			},
+
+			"secure_json_data_encoded": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ValidateFunc: validateDataSourceSecureJSON,
+			},
+		},
+	}
+}
+
+// UpdateDataSourceConfig saves the configured json_data keys and secrets
+// into the data source as Grafana has it. Keys removed from the
+// configuration since it was last applied are removed from Grafana, and all
+// other keys are left to whoever manages them.
+func UpdateDataSourceConfig(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	uid := d.Get("data_source_uid").(string)
+	dataSource, err := client.DataSourceByUID(uid)
+	if err != nil {
+		if isNotFound(err) {
+			return fmt.Errorf("Data source %q not found", uid)
+		}
+		return accessError(err, fmt.Sprintf("reading data source %s", uid))
+	}
+
+	oldJSONData, newJSONData := d.GetChange("json_data_encoded")
+	if dataSource.JSONData == nil {
+		dataSource.JSONData = map[string]interface{}{}
+	}
+	for key := range decodeDataSourceJSON(oldJSONData.(string)) {
+		delete(dataSource.JSONData, key)
+	}
+	for key, value := range decodeDataSourceJSON(newJSONData.(string)) {
+		dataSource.JSONData[key] = value
+	}
+
+	oldSecrets, newSecrets := d.GetChange("secure_json_data_encoded")
+	dataSource.SecureJSONData = removedDataSourceSecrets(oldSecrets.(string), newSecrets.(string))
+	for key, value := range decodeDataSourceSecrets(newSecrets.(string)) {
+		dataSource.SecureJSONData[key] = value
+	}
+
+	if err := client.UpdateDataSource(dataSource); err != nil {
+		return accessError(err, fmt.Sprintf("updating the configuration of data source %s", uid))
+	}
+
+	d.SetId(uid)
+
+	return ReadDataSourceConfig(d, meta)
+}
+
+// ReadDataSourceConfig reads back the json_data keys the resource manages.
+// Imported configurations manage no keys until they are applied.
+func ReadDataSourceConfig(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	dataSource, err := client.DataSourceByUID(d.Id())
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] removing the configuration of data source %s from state because the data source no longer exists in grafana", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return accessError(err, fmt.Sprintf("reading the configuration of data source %s", d.Id()))
+	}
+
+	if encoded := d.Get("json_data_encoded").(string); encoded != "" {
+		jsonData := map[string]interface{}{}
+		for key := range decodeDataSourceJSON(encoded) {
+			if value, ok := dataSource.JSONData[key]; ok {
+				jsonData[key] = value
+			}
+		}
+		d.Set("json_data_encoded", normalizeDataSourceJSON(jsonData))
+	}
+	readSecureJSONDataEncoded(d, dataSource)
+	d.Set("data_source_uid", dataSource.Uid)
+
+	return nil
+}
+
+// DeleteDataSourceConfig removes the json_data keys and secrets the resource
+// manages from the data source, which belongs to whoever created it.
+func DeleteDataSourceConfig(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	dataSource, err := client.DataSourceByUID(d.Id())
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return accessError(err, fmt.Sprintf("reading data source %s", d.Id()))
+	}
+
+	for key := range decodeDataSourceJSON(d.Get("json_data_encoded").(string)) {
+		delete(dataSource.JSONData, key)
+	}
+	dataSource.SecureJSONData = removedDataSourceSecrets(d.Get("secure_json_data_encoded").(string), "")
+
+	err = client.UpdateDataSource(dataSource)
+	if err != nil && !isNotFound(err) {
+		return accessError(err, fmt.Sprintf("removing the configuration of data source %s", d.Id()))
+	}
+
+	return nil
+}
+
+// decodeDataSourceJSON decodes json_data_encoded. The validate function
+// takes care of invalid JSON.
+func decodeDataSourceJSON(encoded string) map[string]interface{} {
+	jsonData := map[string]interface{}{}
+	if encoded != "" {
+		json.Unmarshal([]byte(encoded), &jsonData)
+	}
+	return jsonData
+}
+
+// decodeDataSourceSecrets decodes secure_json_data_encoded. The validate
+// function takes care of invalid JSON.
+func decodeDataSourceSecrets(encoded string) map[string]string {
+	secrets := map[string]string{}
+	if encoded != "" {
+		json.Unmarshal([]byte(encoded), &secrets)
+	}
+	return secrets
+}
+
+// removedDataSourceSecrets returns the secrets that are set in oldEncoded
+// but not in newEncoded, each set to an empty string, which is how Grafana
+// is told to clear them.
+func removedDataSourceSecrets(oldEncoded, newEncoded string) map[string]string {
+	newSecrets := decodeDataSourceSecrets(newEncoded)
+	removed := map[string]string{}
+	for key := range decodeDataSourceSecrets(oldEncoded) {
+		if _, ok := newSecrets[key]; !ok {
+			removed[key] = ""
+		}
+	}
+	return removed
+}
@@ -0,0 +1,196 @@
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	gapi "github.com/nytm/go-grafana-api"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccDataSourceConfig_basic(t *testing.T) {
+	var dataSource gapi.DataSource
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccDataSourceCheckDestroy(&dataSource),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccDataSourceConfigConfig("GET"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceCheckExists("grafana_data_source.test", &dataSource),
+					testAccDataSourceConfigCheckHTTPMethod("grafana_data_source_config.test", "GET"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccDataSourceConfigConfig("POST"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceConfigCheckHTTPMethod("grafana_data_source_config.test", "POST"),
+					resource.TestCheckResourceAttr(
+						"grafana_data_source.test", "url", "http://terraform-acc-test.invalid/",
+					),
+				),
+			},
+			resource.TestStep{
+				ResourceName:            "grafana_data_source_config.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"json_data_encoded", "secure_json_data_encoded"},
+			},
+		},
+	})
+}
+
+func TestDataSourceConfig(t *testing.T) {
+	// The data source is created and managed by someone else, who set
+	// maxLines.
+	remote := gapi.DataSource{
+		Id: 3, Uid: "logs", Name: "logs", Type: "loki",
+		URL: "https://loki.example.net/", Access: "proxy",
+		JSONData:         map[string]interface{}{"maxLines": float64(1000)},
+		SecureJSONFields: map[string]bool{},
+	}
+	var secrets map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/datasources/uid/logs":
+			json.NewEncoder(w).Encode(remote)
+		case r.Method == "PUT" && r.URL.Path == "/api/datasources/3":
+			var saved gapi.DataSource
+			if err := json.NewDecoder(r.Body).Decode(&saved); err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			if saved.Name != "logs" || saved.Type != "loki" || saved.URL != "https://loki.example.net/" || saved.Access != "proxy" {
+				t.Errorf("expected the rest of the data source to be left unchanged, got %#v", saved)
+			}
+			remote.JSONData = saved.JSONData
+			secrets = saved.SecureJSONData
+			for key, value := range secrets {
+				remote.SecureJSONFields[key] = value != ""
+			}
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	d := schema.TestResourceDataRaw(t, ResourceDataSourceConfig().Schema, map[string]interface{}{
+		"data_source_uid":          "logs",
+		"json_data_encoded":        `{"httpHeaderName1": "X-Scope-OrgID"}`,
+		"secure_json_data_encoded": `{"httpHeaderValue1": "tenant"}`,
+	})
+	if err := UpdateDataSourceConfig(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Id() != "logs" {
+		t.Fatalf("expected id logs, got %q", d.Id())
+	}
+	if expected := map[string]interface{}{"maxLines": float64(1000), "httpHeaderName1": "X-Scope-OrgID"}; !reflect.DeepEqual(remote.JSONData, expected) {
+		t.Fatalf("expected json data %v, got %v", expected, remote.JSONData)
+	}
+
+	// Keys managed by the owner of the data source aren't read back.
+	remote.JSONData["maxLines"] = float64(5000)
+	if err := ReadDataSourceConfig(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if encoded := d.Get("json_data_encoded").(string); encoded != `{"httpHeaderName1":"X-Scope-OrgID"}` {
+		t.Fatalf("expected only the managed keys to be read, got %s", encoded)
+	}
+
+	// Keys and secrets removed from the configuration are removed from
+	// Grafana.
+	raw, err := config.NewRawConfig(map[string]interface{}{
+		"data_source_uid":          "logs",
+		"json_data_encoded":        `{"httpHeaderName2": "X-Tenant"}`,
+		"secure_json_data_encoded": `{"httpHeaderValue2": "tenant"}`,
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	diff, err := ResourceDataSourceConfig().Diff(d.State(), terraform.NewResourceConfig(raw))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	state, err := ResourceDataSourceConfig().Apply(d.State(), diff, c)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	d = ResourceDataSourceConfig().Data(state)
+	if expected := map[string]interface{}{"maxLines": float64(5000), "httpHeaderName2": "X-Tenant"}; !reflect.DeepEqual(remote.JSONData, expected) {
+		t.Fatalf("expected json data %v, got %v", expected, remote.JSONData)
+	}
+	if expected := map[string]string{"httpHeaderValue1": "", "httpHeaderValue2": "tenant"}; !reflect.DeepEqual(secrets, expected) {
+		t.Fatalf("expected secrets %v, got %v", expected, secrets)
+	}
+	if encoded := d.Get("secure_json_data_encoded").(string); encoded != `{"httpHeaderValue2":"tenant"}` {
+		t.Fatalf("expected secrets in state to be %s, got %s", `{"httpHeaderValue2":"tenant"}`, encoded)
+	}
+
+	if err := DeleteDataSourceConfig(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if expected := map[string]interface{}{"maxLines": float64(5000)}; !reflect.DeepEqual(remote.JSONData, expected) {
+		t.Fatalf("expected the managed keys to be removed, got %v", remote.JSONData)
+	}
+	if expected := map[string]string{"httpHeaderValue2": ""}; !reflect.DeepEqual(secrets, expected) {
+		t.Fatalf("expected the managed secrets to be cleared, got %v", secrets)
+	}
+}
+
+func testAccDataSourceConfigCheckHTTPMethod(rn, method string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		client := testAccProvider.Meta().(*client).gapi
+		dataSource, err := client.DataSourceByUID(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error getting data source: %s", err)
+		}
+		if dataSource.JSONData["httpMethod"] != method {
+			return fmt.Errorf("expected httpMethod %s, got %v", method, dataSource.JSONData["httpMethod"])
+		}
+
+		return nil
+	}
+}
+
+func testAccDataSourceConfigConfig(method string) string {
+	return fmt.Sprintf(`
+resource "grafana_data_source" "test" {
+    uid  = "tf-acc-test-ds-config"
+    type = "prometheus"
+    name = "terraform-acc-test-ds-config"
+    url  = "http://terraform-acc-test.invalid/"
+
+    json_data_encoded = "{}"
+
+    lifecycle {
+        ignore_changes = ["json_data_encoded"]
+    }
+}
+
+resource "grafana_data_source_config" "test" {
+    data_source_uid = "${grafana_data_source.test.uid}"
+
+    json_data_encoded        = "{\"httpMethod\": \"%s\", \"httpHeaderName1\": \"X-Scope-OrgID\"}"
+    secure_json_data_encoded = "{\"httpHeaderValue1\": \"tenant\"}"
+}
+`, method)
+}
@@ -0,0 +1,164 @@
+package grafana
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	gapi "github.com/nytm/go-grafana-api"
+)
+
+func ResourceDataSourceLBACRules() *schema.Resource {
+	return &schema.Resource{
+		Create: UpdateDataSourceLBACRules,
+		Read:   ReadDataSourceLBACRules,
+		Update: UpdateDataSourceLBACRules,
+		Delete: DeleteDataSourceLBACRules,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDSchema(),
+
+			"data_source_uid": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"rule": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"team_id": &schema.Schema{
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+
+						"label_selectors": &schema.Schema{
+							Type:     schema.TypeList,
+							Required: true,
+							MinItems: 1,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validateLabelSelector,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// UpdateDataSourceLBACRules replaces all the team LBAC rules of the data
+// source with the configured ones.
+func UpdateDataSourceLBACRules(d *schema.ResourceData, meta interface{}) error {
+	if err := meta.(*client).requireEnterprise("grafana_data_source_lbac_rules"); err != nil {
+		return err
+	}
+	if err := meta.(*client).requireVersion("grafana_data_source_lbac_rules", "11.0.0"); err != nil {
+		return err
+	}
+
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	rules, err := makeTeamLBACRules(d)
+	if err != nil {
+		return err
+	}
+
+	uid := d.Get("data_source_uid").(string)
+	if err := client.UpdateDataSourceTeamLBACRules(uid, rules); err != nil {
+		return accessError(err, fmt.Sprintf("updating team LBAC rules of data source %s", uid))
+	}
+
+	d.SetId(uid)
+
+	return ReadDataSourceLBACRules(d, meta)
+}
+
+func ReadDataSourceLBACRules(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	current, err := client.DataSourceTeamLBACRules(d.Id())
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] removing team LBAC rules of data source %s from state because the data source no longer exists in grafana", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return accessError(err, fmt.Sprintf("reading team LBAC rules of data source %s", d.Id()))
+	}
+
+	rules := []interface{}{}
+	for _, rule := range current {
+		rules = append(rules, map[string]interface{}{
+			"team_id":         int(rule.TeamId),
+			"label_selectors": rule.Rules,
+		})
+	}
+
+	d.Set("data_source_uid", d.Id())
+	d.Set("rule", rules)
+
+	return nil
+}
+
+// DeleteDataSourceLBACRules removes all the team LBAC rules of the data
+// source, which gives teams access to all of its data again.
+func DeleteDataSourceLBACRules(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	err = client.UpdateDataSourceTeamLBACRules(d.Id(), nil)
+	if err != nil && !isNotFound(err) {
+		return accessError(err, fmt.Sprintf("removing team LBAC rules of data source %s", d.Id()))
+	}
+
+	return nil
+}
+
+// makeTeamLBACRules converts the rule attribute to the rules of Grafana's
+// API, which takes a single rule per team.
+func makeTeamLBACRules(d *schema.ResourceData) ([]gapi.TeamLBACRule, error) {
+	var rules []gapi.TeamLBACRule
+	teams := map[int64]bool{}
+	for _, r := range d.Get("rule").(*schema.Set).List() {
+		r := r.(map[string]interface{})
+		rule := gapi.TeamLBACRule{
+			TeamId: int64(r["team_id"].(int)),
+		}
+		if teams[rule.TeamId] {
+			return nil, fmt.Errorf("Team %d has more than one rule: give all of its label selectors in a single rule", rule.TeamId)
+		}
+		teams[rule.TeamId] = true
+
+		for _, selector := range r["label_selectors"].([]interface{}) {
+			rule.Rules = append(rule.Rules, selector.(string))
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// validateLabelSelector checks that a label selector is given in braces, as
+// in PromQL and LogQL, e.g. {namespace="prod"}.
+func validateLabelSelector(v interface{}, k string) ([]string, []error) {
+	selector := strings.TrimSpace(v.(string))
+	if !strings.HasPrefix(selector, "{") || !strings.HasSuffix(selector, "}") {
+		return nil, []error{fmt.Errorf("%q must be a label selector in braces, e.g. {namespace=\"prod\"}, got %q", k, v)}
+	}
+	return nil, nil
+}
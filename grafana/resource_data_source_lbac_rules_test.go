@@ -0,0 +1,104 @@
+package grafana
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	gapi "github.com/nytm/go-grafana-api"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestUpdateDataSourceLBACRules(t *testing.T) {
+	var set []gapi.TeamLBACRule
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/frontend/settings":
+			w.Write([]byte(`{"buildInfo": {"version": "11.5.0", "edition": "Enterprise"}}`))
+		case r.Method == "PUT" && r.URL.Path == "/api/datasources/uid/logs/lbac/teams":
+			var body struct {
+				Rules []gapi.TeamLBACRule `json:"rules"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			set = body.Rules
+			w.Write([]byte(`{}`))
+		case r.Method == "GET" && r.URL.Path == "/api/datasources/uid/logs/lbac/teams":
+			json.NewEncoder(w).Encode(map[string]interface{}{"rules": set})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	d := schema.TestResourceDataRaw(t, ResourceDataSourceLBACRules().Schema, map[string]interface{}{
+		"data_source_uid": "logs",
+		"rule": []interface{}{
+			map[string]interface{}{
+				"team_id":         3,
+				"label_selectors": []interface{}{`{namespace="payments"}`, `{cluster="prod", app="api"}`},
+			},
+		},
+	})
+	if err := UpdateDataSourceLBACRules(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := []gapi.TeamLBACRule{
+		{TeamId: 3, Rules: []string{`{namespace="payments"}`, `{cluster="prod", app="api"}`}},
+	}
+	if !reflect.DeepEqual(set, expected) {
+		t.Fatalf("expected rules %v, got %v", expected, set)
+	}
+	if d.Id() != "logs" || d.Get("rule.#").(int) != 1 {
+		t.Fatalf("expected the rule to be read back, got id %q and %d rules", d.Id(), d.Get("rule.#").(int))
+	}
+
+	if err := DeleteDataSourceLBACRules(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(set) != 0 {
+		t.Fatalf("expected the rules to be removed, got %v", set)
+	}
+}
+
+func TestMakeTeamLBACRules_duplicateTeam(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, ResourceDataSourceLBACRules().Schema, map[string]interface{}{
+		"data_source_uid": "logs",
+		"rule": []interface{}{
+			map[string]interface{}{
+				"team_id":         3,
+				"label_selectors": []interface{}{`{namespace="payments"}`},
+			},
+			map[string]interface{}{
+				"team_id":         3,
+				"label_selectors": []interface{}{`{namespace="billing"}`},
+			},
+		},
+	})
+	if _, err := makeTeamLBACRules(d); err == nil || !strings.Contains(err.Error(), "more than one rule") {
+		t.Fatalf("expected an error for two rules of the same team, got %v", err)
+	}
+}
+
+func TestValidateLabelSelector(t *testing.T) {
+	for selector, ok := range map[string]bool{
+		`{namespace="prod"}`:              true,
+		` { app=~"api|web", env!="dev" }`: true,
+		`namespace="prod"`:                false,
+		``:                                false,
+	} {
+		_, errs := validateLabelSelector(selector, "label_selectors.0")
+		if ok != (len(errs) == 0) {
+			t.Errorf("%q: expected valid to be %t, got %v", selector, ok, errs)
+		}
+	}
+}
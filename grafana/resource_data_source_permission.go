@@ -0,0 +1,220 @@
+package grafana
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	gapi "github.com/nytm/go-grafana-api"
+)
+
+func ResourceDataSourcePermission() *schema.Resource {
+	return &schema.Resource{
+		Create: UpdateDataSourcePermission,
+		Read:   ReadDataSourcePermission,
+		Update: UpdateDataSourcePermission,
+		Delete: DeleteDataSourcePermission,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDSchema(),
+
+			"data_source_uid": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"permissions": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"built_in_role": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateStringIn("Viewer", "Editor", "Admin"),
+						},
+
+						"team_id": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+
+						"user_id": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+
+						"permission": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateStringIn("Query", "Edit", "Admin"),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// UpdateDataSourcePermission replaces all the permissions on the data source
+// with the configured ones, revoking the permissions of grantees that aren't
+// configured. Permissions granted by fixed roles are left alone.
+func UpdateDataSourcePermission(d *schema.ResourceData, meta interface{}) error {
+	if err := meta.(*client).requireEnterprise("grafana_data_source_permission"); err != nil {
+		return err
+	}
+	if err := meta.(*client).requireVersion("grafana_data_source_permission", "9.0.0"); err != nil {
+		return err
+	}
+
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	permissions, err := makeDataSourcePermissions(d)
+	if err != nil {
+		return err
+	}
+
+	uid := d.Get("data_source_uid").(string)
+	current, err := client.DataSourcePermissions(uid)
+	if err != nil {
+		return accessError(err, fmt.Sprintf("reading permissions of data source %s", uid))
+	}
+
+	configured := map[gapi.DataSourcePermission]bool{}
+	for _, permission := range permissions {
+		configured[dataSourcePermissionGrantee(permission)] = true
+	}
+	for _, permission := range current {
+		grantee := dataSourcePermissionGrantee(permission)
+		if permission.IsManaged && !configured[grantee] {
+			permissions = append(permissions, grantee)
+			configured[grantee] = true
+		}
+	}
+
+	if err := client.SetDataSourcePermissions(uid, permissions); err != nil {
+		return accessError(err, fmt.Sprintf("updating permissions of data source %s", uid))
+	}
+
+	d.SetId(uid)
+
+	return ReadDataSourcePermission(d, meta)
+}
+
+func ReadDataSourcePermission(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	current, err := client.DataSourcePermissions(d.Id())
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] removing permissions of data source %s from state because the data source no longer exists in grafana", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return accessError(err, fmt.Sprintf("reading permissions of data source %s", d.Id()))
+	}
+
+	permissions := []interface{}{}
+	for _, permission := range current {
+		if !permission.IsManaged {
+			continue
+		}
+		permissions = append(permissions, map[string]interface{}{
+			"built_in_role": permission.BuiltInRole,
+			"team_id":       int(permission.TeamId),
+			"user_id":       int(permission.UserId),
+			"permission":    permission.Permission,
+		})
+	}
+
+	d.Set("data_source_uid", d.Id())
+	d.Set("permissions", permissions)
+
+	return nil
+}
+
+// DeleteDataSourcePermission revokes all the permissions on the data source
+// that aren't granted by fixed roles.
+func DeleteDataSourcePermission(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	current, err := client.DataSourcePermissions(d.Id())
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return accessError(err, fmt.Sprintf("reading permissions of data source %s", d.Id()))
+	}
+
+	var revoked []gapi.DataSourcePermission
+	for _, permission := range current {
+		if permission.IsManaged {
+			revoked = append(revoked, dataSourcePermissionGrantee(permission))
+		}
+	}
+	if len(revoked) == 0 {
+		return nil
+	}
+
+	err = client.SetDataSourcePermissions(d.Id(), revoked)
+	if err != nil && !isNotFound(err) {
+		return accessError(err, fmt.Sprintf("removing permissions of data source %s", d.Id()))
+	}
+
+	return nil
+}
+
+// makeDataSourcePermissions converts the permissions attribute to the
+// permissions of Grafana's access control API.
+func makeDataSourcePermissions(d *schema.ResourceData) ([]gapi.DataSourcePermission, error) {
+	var permissions []gapi.DataSourcePermission
+	for _, p := range d.Get("permissions").(*schema.Set).List() {
+		p := p.(map[string]interface{})
+		permission := gapi.DataSourcePermission{
+			BuiltInRole: p["built_in_role"].(string),
+			TeamId:      int64(p["team_id"].(int)),
+			UserId:      int64(p["user_id"].(int)),
+			Permission:  p["permission"].(string),
+		}
+
+		grantees := 0
+		if permission.BuiltInRole != "" {
+			grantees++
+		}
+		if permission.TeamId != 0 {
+			grantees++
+		}
+		if permission.UserId != 0 {
+			grantees++
+		}
+		if grantees != 1 {
+			return nil, fmt.Errorf("Each permission must set exactly one of built_in_role, team_id or user_id")
+		}
+
+		permissions = append(permissions, permission)
+	}
+	return permissions, nil
+}
+
+// dataSourcePermissionGrantee returns the grantee of a permission without
+// the permission, which revokes it when it's set.
+func dataSourcePermissionGrantee(permission gapi.DataSourcePermission) gapi.DataSourcePermission {
+	return gapi.DataSourcePermission{
+		UserId:      permission.UserId,
+		TeamId:      permission.TeamId,
+		BuiltInRole: permission.BuiltInRole,
+	}
+}
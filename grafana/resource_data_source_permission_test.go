@@ -0,0 +1,179 @@
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	gapi "github.com/nytm/go-grafana-api"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccDataSourcePermission_basic(t *testing.T) {
+	var dataSource gapi.DataSource
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckEnterprise(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccDataSourceCheckDestroy(&dataSource),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccDataSourcePermissionConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceCheckExists("grafana_data_source.test", &dataSource),
+					testAccDataSourcePermissionCheckManaged("grafana_data_source_permission.test", 2),
+					resource.TestCheckResourceAttr(
+						"grafana_data_source_permission.test", "permissions.#", "2",
+					),
+				),
+			},
+			resource.TestStep{
+				Config: testAccDataSourcePermissionConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourcePermissionCheckManaged("grafana_data_source_permission.test", 1),
+					resource.TestCheckResourceAttr(
+						"grafana_data_source_permission.test", "permissions.#", "1",
+					),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "grafana_data_source_permission.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestUpdateDataSourcePermission(t *testing.T) {
+	var set []gapi.DataSourcePermission
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/frontend/settings":
+			w.Write([]byte(`{"buildInfo": {"version": "10.4.0", "edition": "Enterprise"}}`))
+		case r.Method == "GET" && r.URL.Path == "/api/access-control/datasources/metrics":
+			w.Write([]byte(`[
+				{"builtInRole": "Admin", "permission": "Admin", "isManaged": false},
+				{"builtInRole": "Viewer", "permission": "Query", "isManaged": true},
+				{"userId": 7, "permission": "Edit", "isManaged": true}
+			]`))
+		case r.Method == "POST" && r.URL.Path == "/api/access-control/datasources/metrics":
+			var body struct {
+				Permissions []gapi.DataSourcePermission `json:"permissions"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			set = body.Permissions
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	d := schema.TestResourceDataRaw(t, ResourceDataSourcePermission().Schema, map[string]interface{}{
+		"data_source_uid": "metrics",
+		"permissions": []interface{}{
+			map[string]interface{}{"built_in_role": "Viewer", "permission": "Query"},
+			map[string]interface{}{"team_id": 3, "permission": "Edit"},
+		},
+	})
+	if err := UpdateDataSourcePermission(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// The configured permissions are set, and the user's is revoked. The
+	// permission of the fixed Admin role is left alone.
+	expected := map[gapi.DataSourcePermission]bool{
+		{BuiltInRole: "Viewer", Permission: "Query"}: true,
+		{TeamId: 3, Permission: "Edit"}:              true,
+		{UserId: 7}:                                  true,
+	}
+	got := map[gapi.DataSourcePermission]bool{}
+	for _, permission := range set {
+		got[permission] = true
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected permissions %v to be set, got %v", expected, set)
+	}
+	if d.Id() != "metrics" {
+		t.Fatalf("expected id metrics, got %s", d.Id())
+	}
+}
+
+func testAccDataSourcePermissionCheckManaged(rn string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		client := testAccProvider.Meta().(*client).gapi
+		permissions, err := client.DataSourcePermissions(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error getting data source permissions: %s", err)
+		}
+		managed := 0
+		for _, permission := range permissions {
+			if permission.IsManaged {
+				managed++
+			}
+		}
+		if managed != count {
+			return fmt.Errorf("expected %d data source permissions, got %d: %v", count, managed, permissions)
+		}
+
+		return nil
+	}
+}
+
+const testAccDataSourcePermissionConfig_basic = `
+resource "grafana_data_source" "test" {
+    uid  = "tf-acc-test-ds-permission"
+    type = "prometheus"
+    name = "terraform-acc-test-ds-permission"
+    url  = "http://terraform-acc-test.invalid/"
+}
+
+resource "grafana_data_source_permission" "test" {
+    data_source_uid = "${grafana_data_source.test.uid}"
+
+    permissions {
+        built_in_role = "Viewer"
+        permission    = "Query"
+    }
+
+    permissions {
+        built_in_role = "Editor"
+        permission    = "Edit"
+    }
+}
+`
+
+const testAccDataSourcePermissionConfig_update = `
+resource "grafana_data_source" "test" {
+    uid  = "tf-acc-test-ds-permission"
+    type = "prometheus"
+    name = "terraform-acc-test-ds-permission"
+    url  = "http://terraform-acc-test.invalid/"
+}
+
+resource "grafana_data_source_permission" "test" {
+    data_source_uid = "${grafana_data_source.test.uid}"
+
+    permissions {
+        built_in_role = "Editor"
+        permission    = "Query"
+    }
+}
+`
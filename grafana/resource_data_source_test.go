@@ -1,14 +1,21 @@
 package grafana
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"regexp"
 	"strconv"
+	"strings"
 	"testing"
 
 	gapi "github.com/nytm/go-grafana-api"
 
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 )
 
@@ -36,8 +43,24 @@ func TestAccDataSource_basic(t *testing.T) {
 					resource.TestMatchResourceAttr(
 						"grafana_data_source.test_influxdb", "id", regexp.MustCompile(`\d+`),
 					),
+					resource.TestCheckResourceAttr(
+						"grafana_data_source.test_influxdb", "uid", "terraform-acc-test-influxdb",
+					),
 				),
 			},
+			resource.TestStep{
+				ResourceName:            "grafana_data_source.test_influxdb",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password", "basic_auth_password"},
+			},
+			resource.TestStep{
+				ResourceName:            "grafana_data_source.test_influxdb",
+				ImportState:             true,
+				ImportStateId:           "terraform-acc-test-influxdb",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password", "basic_auth_password"},
+			},
 		},
 	})
 }
@@ -78,6 +101,483 @@ func TestAccDataSource_basicCloudwatch(t *testing.T) {
 	})
 }
 
+func TestAccDataSource_orgID(t *testing.T) {
+	var dataSource gapi.DataSource
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccDataSourceCheckDestroy(&dataSource),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccDataSourceConfig_orgID,
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceCheckExists("grafana_data_source.test_org", &dataSource),
+					resource.TestCheckResourceAttr(
+						"grafana_data_source.test_org", "org_id", "1",
+					),
+				),
+			},
+		},
+	})
+}
+
+func TestReadDataSource_secrets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/datasources/1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		// The secret key and basic auth password were reset in Grafana.
+		w.Write([]byte(`{
+			"id": 1,
+			"name": "cloudwatch",
+			"type": "cloudwatch",
+			"secureJsonFields": {"password": true, "accessKey": true}
+		}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	d := schema.TestResourceDataRaw(t, ResourceDataSource().Schema, map[string]interface{}{
+		"name":                "cloudwatch",
+		"type":                "cloudwatch",
+		"password":            "password",
+		"basic_auth_password": "basic_password",
+		"secure_json_data": []interface{}{
+			map[string]interface{}{
+				"access_key": "123",
+				"secret_key": "456",
+			},
+		},
+	})
+	d.SetId("1")
+
+	if err := ReadDataSource(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := map[string]string{
+		"password":                      "password",
+		"basic_auth_password":           "",
+		"secure_json_data.0.access_key": "123",
+		"secure_json_data.0.secret_key": "",
+	}
+	for key, value := range expected {
+		if got := d.Get(key).(string); got != value {
+			t.Errorf("expected %s to be %q, got %q", key, value, got)
+		}
+	}
+}
+
+func TestUpdateDataSource_removedSecrets(t *testing.T) {
+	var secrets map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "PUT /api/datasources/1":
+			var saved gapi.DataSource
+			if err := json.NewDecoder(r.Body).Decode(&saved); err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			secrets = saved.SecureJSONData
+			w.Write([]byte(`{}`))
+		case "GET /api/datasources/1":
+			w.Write([]byte(`{"id": 1, "name": "cloudwatch", "type": "cloudwatch", "secureJsonFields": {"accessKey": true, "httpHeaderValue1": true}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	state := &terraform.InstanceState{
+		ID: "1",
+		Attributes: map[string]string{
+			"name":                          "cloudwatch",
+			"type":                          "cloudwatch",
+			"password":                      "password",
+			"json_data.#":                   "1",
+			"json_data.0.auth_type":         "keys",
+			"json_data.0.default_region":    "us-east-1",
+			"secure_json_data.#":            "1",
+			"secure_json_data.0.access_key": "123",
+			"secure_json_data.0.secret_key": "456",
+			"http_header.#":                 "2",
+			"http_header.0.name":            "X-Scope-OrgID",
+			"http_header.0.value":           "tenant",
+			"http_header.1.name":            "X-Custom",
+			"http_header.1.value":           "secret",
+		},
+	}
+	raw, err := config.NewRawConfig(map[string]interface{}{
+		"name": "cloudwatch",
+		"type": "cloudwatch",
+		"json_data": []interface{}{
+			map[string]interface{}{"auth_type": "keys", "default_region": "us-east-1"},
+		},
+		"secure_json_data": []interface{}{
+			map[string]interface{}{"access_key": "123"},
+		},
+		"http_header": []interface{}{
+			map[string]interface{}{"name": "X-Scope-OrgID", "value": "tenant"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	diff, err := ResourceDataSource().Diff(state, terraform.NewResourceConfig(raw))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if _, err := ResourceDataSource().Apply(state, diff, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := map[string]string{
+		"password":         "",
+		"accessKey":        "123",
+		"secretKey":        "",
+		"httpHeaderValue1": "tenant",
+		"httpHeaderValue2": "",
+	}
+	if !reflect.DeepEqual(secrets, expected) {
+		t.Fatalf("expected the removed secrets to be cleared with %v, got %v", expected, secrets)
+	}
+}
+
+func TestValidateDataSourceType(t *testing.T) {
+	cases := []struct {
+		raw map[string]interface{}
+		err string
+	}{
+		{
+			raw: map[string]interface{}{
+				"type": "prometheus",
+				"json_data": []interface{}{
+					map[string]interface{}{"http_method": "POST", "tls_skip_verify": true},
+				},
+			},
+		},
+		{
+			raw: map[string]interface{}{
+				"type": "cloudwatch",
+				"json_data": []interface{}{
+					map[string]interface{}{"auth_type": "keys"},
+				},
+			},
+			err: "json_data.0.default_region is required for data sources of type cloudwatch",
+		},
+		{
+			raw: map[string]interface{}{
+				"type":          "postgres",
+				"database_name": "metrics",
+				"json_data": []interface{}{
+					map[string]interface{}{"es_version": "7.10.0", "ssl_mode": "disable"},
+				},
+			},
+			err: "json_data.0.es_version doesn't apply to data sources of type postgres, only elasticsearch",
+		},
+		{
+			raw: map[string]interface{}{
+				"type":              "cloudwatch",
+				"json_data_encoded": `{"authType": "keys", "defaultRegion": "us-east-1"}`,
+			},
+		},
+		{
+			raw: map[string]interface{}{
+				"type": "some-plugin-datasource",
+				"json_data": []interface{}{
+					map[string]interface{}{"http_method": "GET"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		d := schema.TestResourceDataRaw(t, ResourceDataSource().Schema, tc.raw)
+		err := validateDataSourceType(d)
+		if tc.err == "" {
+			if err != nil {
+				t.Errorf("%v: unexpected error: %s", tc.raw, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("%v: expected error %q, got %v", tc.raw, tc.err, err)
+		}
+	}
+}
+
+func TestReadDataSource_encoded(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{
+			"id": 1,
+			"name": "tempo",
+			"type": "tempo",
+			"jsonData": {"tracesToLogs": {"datasourceUid": "loki"}, "httpMethod": "GET"},
+			"secureJsonFields": {"httpHeaderValue1": true}
+		}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	d := schema.TestResourceDataRaw(t, ResourceDataSource().Schema, map[string]interface{}{
+		"name":                     "tempo",
+		"type":                     "tempo",
+		"json_data_encoded":        `{"tracesToLogs": {"datasourceUid": "loki"}}`,
+		"secure_json_data_encoded": `{"httpHeaderValue1": "token", "httpHeaderValue2": "removed"}`,
+	})
+	d.SetId("1")
+
+	if err := ReadDataSource(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := map[string]string{
+		"json_data_encoded":        `{"httpMethod":"GET","tracesToLogs":{"datasourceUid":"loki"}}`,
+		"secure_json_data_encoded": `{"httpHeaderValue1":"token"}`,
+	}
+	for key, value := range expected {
+		if got := d.Get(key).(string); got != value {
+			t.Errorf("expected %s to be %q, got %q", key, value, got)
+		}
+	}
+	if jsonData := d.Get("json_data").([]interface{}); len(jsonData) != 0 {
+		t.Errorf("expected no json_data alongside json_data_encoded, got %v", jsonData)
+	}
+}
+
+func TestReadDataSource_jsonDataTypes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{
+			"id": 1,
+			"name": "logs",
+			"type": "elasticsearch",
+			"database": "[logs-]YYYY.MM.DD",
+			"jsonData": {"esVersion": 70, "tlsSkipVerify": "true", "maxConcurrentShardRequests": "5", "timeField": ["@timestamp"]}
+		}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	d := schema.TestResourceDataRaw(t, ResourceDataSource().Schema, map[string]interface{}{
+		"name": "logs",
+		"type": "elasticsearch",
+	})
+	d.SetId("1")
+
+	if err := ReadDataSource(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"json_data.0.es_version":                    "70",
+		"json_data.0.tls_skip_verify":               true,
+		"json_data.0.max_concurrent_shard_requests": 5,
+		"json_data.0.time_field":                    "",
+	}
+	for key, value := range expected {
+		if got := d.Get(key); got != value {
+			t.Errorf("expected %s to be %#v, got %#v", key, value, got)
+		}
+	}
+}
+
+func TestImportDataSource_uid(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/datasources/uid/metrics" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "Data source not found"}`))
+			return
+		}
+		w.Write([]byte(`{"id": 12, "uid": "metrics", "name": "metrics", "type": "prometheus"}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	for id, expected := range map[string]string{"12": "12", "metrics": "12"} {
+		d := schema.TestResourceDataRaw(t, ResourceDataSource().Schema, map[string]interface{}{})
+		d.SetId(id)
+		if _, err := ImportDataSource(d, c); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if d.Id() != expected {
+			t.Errorf("expected %s to be imported as %s, got %s", id, expected, d.Id())
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, ResourceDataSource().Schema, map[string]interface{}{})
+	d.SetId("missing")
+	if _, err := ImportDataSource(d, c); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
+
+func TestCreateDataSource_verify(t *testing.T) {
+	for health, ok := range map[int]bool{http.StatusOK: true, http.StatusNotFound: true, http.StatusBadRequest: false} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == "POST" && r.URL.Path == "/api/datasources":
+				w.Write([]byte(`{"id": 1}`))
+			case r.Method == "GET" && r.URL.Path == "/api/datasources/1/health":
+				w.WriteHeader(health)
+				w.Write([]byte(`{"status": "ERROR", "message": "connection refused"}`))
+			case r.Method == "GET" && r.URL.Path == "/api/datasources/1":
+				w.Write([]byte(`{"id": 1, "name": "prometheus", "type": "prometheus"}`))
+			default:
+				t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+
+		c := newTestClient(t, server)
+
+		d := schema.TestResourceDataRaw(t, ResourceDataSource().Schema, map[string]interface{}{
+			"name":   "prometheus",
+			"type":   "prometheus",
+			"verify": true,
+		})
+		err := CreateDataSource(d, c)
+		server.Close()
+
+		if ok && err != nil {
+			t.Errorf("%d: expected the data source to be verified, got %s", health, err)
+		}
+		if !ok && (err == nil || !strings.Contains(err.Error(), "connection refused")) {
+			t.Errorf("%d: expected a health check error, got %v", health, err)
+		}
+		if d.Id() != "1" {
+			t.Errorf("%d: expected the created data source to be kept in state, got id %q", health, d.Id())
+		}
+	}
+}
+
+func TestCreateDataSource_conflict(t *testing.T) {
+	if !ResourceDataSource().Schema["type"].ForceNew {
+		t.Fatalf("expected changing the type to replace the data source")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/datasources" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"message": "data source with the same name already exists"}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	d := schema.TestResourceDataRaw(t, ResourceDataSource().Schema, map[string]interface{}{
+		"name":       "metrics",
+		"type":       "loki",
+		"is_default": true,
+	})
+	err := CreateDataSource(d, c)
+	if err == nil || !strings.Contains(err.Error(), "name or uid already exists") || !strings.Contains(err.Error(), "same name already exists") {
+		t.Fatalf("expected a conflict error, got %v", err)
+	}
+	if d.Id() != "" {
+		t.Fatalf("expected no data source in state, got id %q", d.Id())
+	}
+	if err := c.claimDefaultDataSource(0, "logs"); err != nil {
+		t.Fatalf("expected the data source that wasn't created to give up the default, got %s", err)
+	}
+}
+
+func TestReadDataSource_default(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Another data source was made the default in Grafana.
+		switch r.URL.Path {
+		case "/api/datasources/1":
+			w.Write([]byte(`{"id": 1, "name": "prometheus", "type": "prometheus", "isDefault": false}`))
+		case "/api/datasources/2":
+			w.Write([]byte(`{"id": 2, "name": "loki", "type": "loki", "isDefault": true}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	state := &terraform.InstanceState{
+		ID: "1",
+		Attributes: map[string]string{
+			"name":       "prometheus",
+			"type":       "prometheus",
+			"is_default": "true",
+		},
+	}
+	d := ResourceDataSource().Data(state)
+	if err := ReadDataSource(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	raw, err := config.NewRawConfig(map[string]interface{}{
+		"name":       "prometheus",
+		"type":       "prometheus",
+		"is_default": true,
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	diff, err := ResourceDataSource().Diff(d.State(), terraform.NewResourceConfig(raw))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if attr, ok := diff.Attributes["is_default"]; !ok || attr.New != "true" {
+		t.Fatalf("expected a change to make the data source the default again, got %v", diff.Attributes)
+	}
+
+	// The data source that was given the default in Grafana is configured
+	// as the default too.
+	d = ResourceDataSource().Data(&terraform.InstanceState{
+		ID: "2",
+		Attributes: map[string]string{
+			"name":       "loki",
+			"type":       "loki",
+			"is_default": "true",
+		},
+	})
+	if err := ReadDataSource(d, c); err == nil || !strings.Contains(err.Error(), "both have is_default set") {
+		t.Fatalf("expected two default data sources to be rejected, got %v", err)
+	}
+}
+
+func TestReadJSONData(t *testing.T) {
+	block := readJSONData(map[string]interface{}{
+		"httpMethod":    "POST",
+		"tlsSkipVerify": true,
+		"maxOpenConns":  float64(10),
+		"esVersion":     float64(7.10),
+		"timescaledb":   "sometimes",
+		"unknownKey":    "ignored",
+	})
+	expected := []interface{}{
+		map[string]interface{}{
+			"http_method":     "POST",
+			"tls_skip_verify": true,
+			"max_open_conns":  10,
+			"es_version":      "7.1",
+		},
+	}
+	if !reflect.DeepEqual(block, expected) {
+		t.Fatalf("expected %v, got %v", expected, block)
+	}
+	if block := readJSONData(map[string]interface{}{"unknownKey": "ignored"}); block != nil {
+		t.Fatalf("expected no block, got %v", block)
+	}
+}
+
 func testAccDataSourceCheckExists(rn string, dataSource *gapi.DataSource) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[rn]
@@ -94,7 +594,7 @@ func testAccDataSourceCheckExists(rn string, dataSource *gapi.DataSource) resour
 			return fmt.Errorf("resource id is malformed")
 		}
 
-		client := testAccProvider.Meta().(*gapi.Client)
+		client := testAccProvider.Meta().(*client).gapi
 		gotDataSource, err := client.DataSource(id)
 		if err != nil {
 			return fmt.Errorf("error getting data source: %s", err)
@@ -108,7 +608,7 @@ func testAccDataSourceCheckExists(rn string, dataSource *gapi.DataSource) resour
 
 func testAccDataSourceCheckDestroy(dataSource *gapi.DataSource) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*gapi.Client)
+		client := testAccProvider.Meta().(*client).gapi
 		_, err := client.DataSource(dataSource.Id)
 		if err == nil {
 			return fmt.Errorf("data source still exists")
@@ -119,6 +619,7 @@ func testAccDataSourceCheckDestroy(dataSource *gapi.DataSource) resource.TestChe
 
 const testAccDataSourceConfig_basic = `
 resource "grafana_data_source" "test_influxdb" {
+  uid                 = "terraform-acc-test-influxdb"
   type                = "influxdb"
   name                = "terraform-acc-test-influxdb"
   database_name       = "terraform-acc-test-influxdb"
@@ -146,3 +647,12 @@ resource "grafana_data_source" "test_cloudwatch" {
   }
 }
 `
+const testAccDataSourceConfig_orgID = `
+resource "grafana_data_source" "test_org" {
+  org_id        = 1
+  type          = "influxdb"
+  name          = "terraform-acc-test-org"
+  database_name = "terraform-acc-test-org"
+  url           = "http://terraform-acc-test.invalid/"
+}
+`
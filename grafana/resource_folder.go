@@ -0,0 +1,163 @@
+package grafana
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func ResourceFolder() *schema.Resource {
+	return &schema.Resource{
+		Create: CreateFolder,
+		Read:   ReadFolder,
+		Update: UpdateFolder,
+		Delete: DeleteFolder,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDSchema(),
+
+			"title": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"uid": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"parent_folder_uid": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"folder_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"slug": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"url": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func CreateFolder(d *schema.ResourceData, meta interface{}) error {
+	if d.Get("parent_folder_uid").(string) != "" {
+		if err := meta.(*client).requireVersion("parent_folder_uid", "11.0.0"); err != nil {
+			return err
+		}
+	}
+
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	folder, err := client.NewFolder(d.Get("title").(string), d.Get("uid").(string), d.Get("parent_folder_uid").(string))
+	if err != nil {
+		return accessError(err, "creating folder")
+	}
+
+	d.SetId(folder.Uid)
+
+	return ReadFolder(d, meta)
+}
+
+func ReadFolder(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	folder, err := client.Folder(d.Id())
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] removing folder %s from state because it no longer exists in grafana", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return accessError(err, fmt.Sprintf("reading folder %s", d.Id()))
+	}
+
+	d.Set("title", folder.Title)
+	d.Set("uid", folder.Uid)
+	d.Set("parent_folder_uid", folder.ParentUid)
+	d.Set("folder_id", folder.Id)
+	d.Set("version", folder.Version)
+	d.Set("slug", folderSlug(folder.Url))
+	d.Set("url", folder.Url)
+
+	return nil
+}
+
+func UpdateFolder(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange("parent_folder_uid") {
+		if err := meta.(*client).requireVersion("parent_folder_uid", "11.0.0"); err != nil {
+			return err
+		}
+	}
+
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange("title") {
+		if err := client.UpdateFolder(d.Id(), d.Get("title").(string)); err != nil {
+			return accessError(err, fmt.Sprintf("updating folder %s", d.Id()))
+		}
+	}
+
+	if d.HasChange("parent_folder_uid") {
+		if err := client.MoveFolder(d.Id(), d.Get("parent_folder_uid").(string)); err != nil {
+			return accessError(err, fmt.Sprintf("moving folder %s", d.Id()))
+		}
+	}
+
+	return ReadFolder(d, meta)
+}
+
+func DeleteFolder(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	err = client.DeleteFolder(d.Id())
+	if err != nil && !isNotFound(err) {
+		return accessError(err, fmt.Sprintf("deleting folder %s", d.Id()))
+	}
+
+	return nil
+}
+
+// folderSlug returns the slug of a folder, which the folder API doesn't
+// return on its own: it is the last element of the folder's URL, such as
+// /dashboards/f/cIBgcSjkk/metrics.
+func folderSlug(url string) string {
+	parts := strings.Split(strings.TrimSuffix(url, "/"), "/")
+	if len(parts) < 5 || parts[len(parts)-3] != "f" {
+		return ""
+	}
+	return parts[len(parts)-1]
+}
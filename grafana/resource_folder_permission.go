@@ -0,0 +1,93 @@
+package grafana
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func ResourceFolderPermission() *schema.Resource {
+	return &schema.Resource{
+		Create: UpdateFolderPermission,
+		Read:   ReadFolderPermission,
+		Update: UpdateFolderPermission,
+		Delete: DeleteFolderPermission,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDSchema(),
+
+			"folder_uid": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"permissions": permissionsSchema(),
+		},
+	}
+}
+
+// UpdateFolderPermission replaces all the permissions of the folder with the
+// configured ones.
+func UpdateFolderPermission(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	items, err := makePermissionItems(d)
+	if err != nil {
+		return err
+	}
+
+	uid := d.Get("folder_uid").(string)
+	if err := client.UpdateFolderPermissions(uid, items); err != nil {
+		return accessError(err, fmt.Sprintf("updating permissions of folder %s", uid))
+	}
+
+	d.SetId(uid)
+
+	return ReadFolderPermission(d, meta)
+}
+
+func ReadFolderPermission(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	items, err := client.FolderPermissions(d.Id())
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] removing permissions of folder %s from state because the folder no longer exists in grafana", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return accessError(err, fmt.Sprintf("reading permissions of folder %s", d.Id()))
+	}
+
+	d.Set("folder_uid", d.Id())
+	d.Set("permissions", flattenPermissionItems(items))
+
+	return nil
+}
+
+// DeleteFolderPermission removes all the permissions of the folder, which
+// leaves it accessible to organization admins only.
+func DeleteFolderPermission(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	err = client.UpdateFolderPermissions(d.Id(), nil)
+	if err != nil && !isNotFound(err) {
+		return accessError(err, fmt.Sprintf("removing permissions of folder %s", d.Id()))
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package grafana
+
+import (
+	"fmt"
+	"testing"
+
+	gapi "github.com/nytm/go-grafana-api"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccFolderPermission_basic(t *testing.T) {
+	var folder gapi.Folder
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccFolderCheckDestroy(&folder),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccFolderPermissionConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccFolderCheckExists("grafana_folder.test", &folder),
+					testAccFolderPermissionCheckItems("grafana_folder_permission.test", 2),
+					resource.TestCheckResourceAttr(
+						"grafana_folder_permission.test", "permissions.#", "2",
+					),
+				),
+			},
+			resource.TestStep{
+				Config: testAccFolderPermissionConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccFolderPermissionCheckItems("grafana_folder_permission.test", 1),
+					resource.TestCheckResourceAttr(
+						"grafana_folder_permission.test", "permissions.#", "1",
+					),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "grafana_folder_permission.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccFolderPermissionCheckItems(rn string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		client := testAccProvider.Meta().(*client).gapi
+		items, err := client.FolderPermissions(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error getting folder permissions: %s", err)
+		}
+		if len(items) != count {
+			return fmt.Errorf("expected %d folder permissions, got %d: %v", count, len(items), items)
+		}
+
+		return nil
+	}
+}
+
+const testAccFolderPermissionConfig_basic = `
+resource "grafana_folder" "test" {
+    title = "Terraform Acceptance Test Folder Permissions"
+}
+
+resource "grafana_folder_permission" "test" {
+    folder_uid = "${grafana_folder.test.id}"
+
+    permissions {
+        role       = "Editor"
+        permission = "Edit"
+    }
+
+    permissions {
+        user_id    = 1
+        permission = "Admin"
+    }
+}
+`
+
+const testAccFolderPermissionConfig_update = `
+resource "grafana_folder" "test" {
+    title = "Terraform Acceptance Test Folder Permissions"
+}
+
+resource "grafana_folder_permission" "test" {
+    folder_uid = "${grafana_folder.test.id}"
+
+    permissions {
+        role       = "Viewer"
+        permission = "View"
+    }
+}
+`
@@ -0,0 +1,247 @@
+package grafana
+
+import (
+	"fmt"
+	"testing"
+
+	gapi "github.com/nytm/go-grafana-api"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccFolder_basic(t *testing.T) {
+	var folder gapi.Folder
+	var dashboard gapi.Dashboard
+	var dashboardUID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccFolderCheckDestroy(&folder),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccFolderConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccFolderCheckExists("grafana_folder.test", &folder),
+					testAccDashboardCheckExists("grafana_dashboard.test", &dashboard),
+					resource.TestCheckResourceAttr(
+						"grafana_folder.test", "title", "Terraform Acceptance Test Folder",
+					),
+					resource.TestCheckResourceAttr(
+						"grafana_folder.test", "uid", "tf-acc-test-folder",
+					),
+					resource.TestCheckResourceAttr(
+						"grafana_dashboard.test", "folder", "tf-acc-test-folder",
+					),
+					resource.TestCheckResourceAttr(
+						"grafana_folder.test", "slug", "terraform-acceptance-test-folder",
+					),
+					resource.TestCheckResourceAttr(
+						"grafana_folder.test", "url", "/dashboards/f/tf-acc-test-folder/terraform-acceptance-test-folder",
+					),
+					resource.TestCheckResourceAttr(
+						"grafana_folder.test", "version", "1",
+					),
+					func(s *terraform.State) error {
+						dashboardUID = s.RootModule().Resources["grafana_dashboard.test"].Primary.ID
+						return nil
+					},
+				),
+			},
+			resource.TestStep{
+				Config: testAccFolderConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccFolderCheckExists("grafana_folder.test", &folder),
+					resource.TestCheckResourceAttr(
+						"grafana_folder.test", "title", "Terraform Acceptance Test Folder Renamed",
+					),
+					resource.TestCheckResourceAttr(
+						"grafana_folder.test", "slug", "terraform-acceptance-test-folder-renamed",
+					),
+					resource.TestCheckResourceAttr(
+						"grafana_folder.test", "version", "2",
+					),
+				),
+			},
+			// Moving the dashboard out of the folder updates it in place.
+			resource.TestStep{
+				Config: testAccFolderConfig_moveDashboard,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"grafana_dashboard.test", "folder", "",
+					),
+					resource.TestCheckResourceAttrPtr(
+						"grafana_dashboard.test", "id", &dashboardUID,
+					),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "grafana_folder.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccFolder_nested(t *testing.T) {
+	var folder gapi.Folder
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccFolderCheckDestroy(&folder),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccFolderConfig_nested("${grafana_folder.parent.uid}"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccFolderCheckExists("grafana_folder.child", &folder),
+					resource.TestCheckResourceAttr(
+						"grafana_folder.child", "parent_folder_uid", "tf-acc-test-parent",
+					),
+				),
+			},
+			// Moving the folder to another parent updates it in place.
+			resource.TestStep{
+				Config: testAccFolderConfig_nested("${grafana_folder.other_parent.uid}"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccFolderCheckExists("grafana_folder.child", &folder),
+					resource.TestCheckResourceAttr(
+						"grafana_folder.child", "parent_folder_uid", "tf-acc-test-other-parent",
+					),
+				),
+			},
+			resource.TestStep{
+				Config: testAccFolderConfig_nested(""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccFolderCheckExists("grafana_folder.child", &folder),
+					resource.TestCheckResourceAttr(
+						"grafana_folder.child", "parent_folder_uid", "",
+					),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "grafana_folder.child",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestFolderSlug(t *testing.T) {
+	cases := map[string]string{
+		"/dashboards/f/cIBgcSjkk/metrics":         "metrics",
+		"/grafana/dashboards/f/cIBgcSjkk/metrics": "metrics",
+		"/dashboards/f/cIBgcSjkk/":                "",
+		"/d/cIBgcSjkk/metrics":                    "",
+		"":                                        "",
+	}
+	for url, want := range cases {
+		if got := folderSlug(url); got != want {
+			t.Errorf("folderSlug(%q) = %q, want %q", url, got, want)
+		}
+	}
+}
+
+func testAccFolderCheckExists(rn string, folder *gapi.Folder) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("resource id not set")
+		}
+
+		client := testAccProvider.Meta().(*client).gapi
+		gotFolder, err := client.Folder(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error getting folder: %s", err)
+		}
+
+		*folder = *gotFolder
+
+		return nil
+	}
+}
+
+func testAccFolderCheckDestroy(folder *gapi.Folder) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*client).gapi
+		_, err := client.Folder(folder.Uid)
+		if err == nil {
+			return fmt.Errorf("folder still exists")
+		}
+		return nil
+	}
+}
+
+const testAccFolderConfig_basic = `
+resource "grafana_folder" "test" {
+    title = "Terraform Acceptance Test Folder"
+    uid   = "tf-acc-test-folder"
+}
+
+resource "grafana_dashboard" "test" {
+    folder      = "${grafana_folder.test.id}"
+    config_json = <<EOT
+{
+    "title": "Terraform Acceptance Test Folder Dashboard"
+}
+EOT
+}
+`
+
+const testAccFolderConfig_update = `
+resource "grafana_folder" "test" {
+    title = "Terraform Acceptance Test Folder Renamed"
+    uid   = "tf-acc-test-folder"
+}
+
+resource "grafana_dashboard" "test" {
+    folder      = "${grafana_folder.test.id}"
+    config_json = <<EOT
+{
+    "title": "Terraform Acceptance Test Folder Dashboard"
+}
+EOT
+}
+`
+
+const testAccFolderConfig_moveDashboard = `
+resource "grafana_folder" "test" {
+    title = "Terraform Acceptance Test Folder Renamed"
+    uid   = "tf-acc-test-folder"
+}
+
+resource "grafana_dashboard" "test" {
+    config_json = <<EOT
+{
+    "title": "Terraform Acceptance Test Folder Dashboard"
+}
+EOT
+}
+`
+
+func testAccFolderConfig_nested(parentUID string) string {
+	return fmt.Sprintf(`
+resource "grafana_folder" "parent" {
+    title = "Terraform Acceptance Test Parent Folder"
+    uid   = "tf-acc-test-parent"
+}
+
+resource "grafana_folder" "other_parent" {
+    title = "Terraform Acceptance Test Other Parent Folder"
+    uid   = "tf-acc-test-other-parent"
+}
+
+resource "grafana_folder" "child" {
+    title             = "Terraform Acceptance Test Child Folder"
+    uid               = "tf-acc-test-child"
+    parent_folder_uid = "%s"
+}
+`, parentUID)
+}
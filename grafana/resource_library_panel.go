@@ -0,0 +1,198 @@
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	gapi "github.com/nytm/go-grafana-api"
+)
+
+func ResourceLibraryPanel() *schema.Resource {
+	return &schema.Resource{
+		Create: CreateLibraryPanel,
+		Read:   ReadLibraryPanel,
+		Update: UpdateLibraryPanel,
+		Delete: DeleteLibraryPanel,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDSchema(),
+
+			"uid": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"folder": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"model_json": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				StateFunc:    normalizeLibraryPanelModelJSON,
+				ValidateFunc: validateLibraryPanelModelJSON,
+			},
+
+			"panel_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func CreateLibraryPanel(d *schema.ResourceData, meta interface{}) error {
+	if err := meta.(*client).requireVersion("grafana_library_panel", "8.0.0"); err != nil {
+		return err
+	}
+
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	panel, err := makeLibraryPanel(d)
+	if err != nil {
+		return err
+	}
+	panel.Uid = d.Get("uid").(string)
+
+	resp, err := client.NewLibraryPanel(panel)
+	if err != nil {
+		return accessError(err, "creating library panel")
+	}
+
+	d.SetId(resp.Uid)
+
+	return ReadLibraryPanel(d, meta)
+}
+
+func ReadLibraryPanel(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	panel, err := client.LibraryPanel(d.Id())
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] removing library panel %s from state because it no longer exists in grafana", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return accessError(err, fmt.Sprintf("reading library panel %s", d.Id()))
+	}
+
+	modelJSON, err := json.Marshal(panel.Model)
+	if err != nil {
+		return err
+	}
+
+	d.Set("uid", panel.Uid)
+	d.Set("name", panel.Name)
+	d.Set("folder", panel.FolderUid)
+	d.Set("model_json", normalizeLibraryPanelModelJSON(string(modelJSON)))
+	d.Set("panel_id", panel.Id)
+	d.Set("version", panel.Version)
+
+	return nil
+}
+
+func UpdateLibraryPanel(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	panel, err := makeLibraryPanel(d)
+	if err != nil {
+		return err
+	}
+	// Grafana refuses to save over a panel that changed since it was read.
+	panel.Version = int64(d.Get("version").(int))
+
+	if _, err := client.UpdateLibraryPanel(d.Id(), panel); err != nil {
+		return accessError(err, fmt.Sprintf("updating library panel %s", d.Id()))
+	}
+
+	return ReadLibraryPanel(d, meta)
+}
+
+func DeleteLibraryPanel(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	err = client.DeleteLibraryPanel(d.Id())
+	if err != nil && !isNotFound(err) {
+		return accessError(err, fmt.Sprintf("deleting library panel %s", d.Id()))
+	}
+
+	return nil
+}
+
+func makeLibraryPanel(d *schema.ResourceData) (gapi.LibraryPanel, error) {
+	model := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(d.Get("model_json").(string)), &model); err != nil {
+		return gapi.LibraryPanel{}, err
+	}
+
+	return gapi.LibraryPanel{
+		Name:      d.Get("name").(string),
+		FolderUid: d.Get("folder").(string),
+		Model:     model,
+	}, nil
+}
+
+func validateLibraryPanelModelJSON(v interface{}, k string) ([]string, []error) {
+	model := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(v.(string)), &model); err != nil {
+		return nil, []error{fmt.Errorf("%s must be a JSON object: %s", k, err)}
+	}
+	return nil, nil
+}
+
+// normalizeLibraryPanelModelJSON sorts the keys of a library panel's model,
+// and removes the properties that Grafana manages: the position and id the
+// panel has on the dashboard it was made from, and the reference to the
+// library panel that Grafana adds when it's used on a dashboard.
+func normalizeLibraryPanelModelJSON(v interface{}) string {
+	modelJSON := v.(string)
+
+	model := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(modelJSON), &model); err != nil {
+		// The validate function should've taken care of this.
+		return ""
+	}
+
+	delete(model, "id")
+	delete(model, "gridPos")
+	delete(model, "libraryPanel")
+
+	ret, err := json.Marshal(model)
+	if err != nil {
+		// Should never happen.
+		return modelJSON
+	}
+
+	return string(ret)
+}
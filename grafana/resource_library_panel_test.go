@@ -0,0 +1,128 @@
+package grafana
+
+import (
+	"fmt"
+	"testing"
+
+	gapi "github.com/nytm/go-grafana-api"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccLibraryPanel_basic(t *testing.T) {
+	var panel gapi.LibraryPanel
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccLibraryPanelCheckDestroy(&panel),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccLibraryPanelConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccLibraryPanelCheckExists("grafana_library_panel.test", &panel),
+					resource.TestCheckResourceAttr(
+						"grafana_library_panel.test", "uid", "tf-acc-test-panel",
+					),
+					resource.TestCheckResourceAttr(
+						"grafana_library_panel.test", "version", "1",
+					),
+				),
+			},
+			resource.TestStep{
+				Config: testAccLibraryPanelConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccLibraryPanelCheckExists("grafana_library_panel.test", &panel),
+					resource.TestCheckResourceAttr(
+						"grafana_library_panel.test", "name", "Terraform Acceptance Test Panel Updated",
+					),
+					resource.TestCheckResourceAttr(
+						"grafana_library_panel.test", "version", "2",
+					),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "grafana_library_panel.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestNormalizeLibraryPanelModelJSON(t *testing.T) {
+	got := normalizeLibraryPanelModelJSON(`{
+		"type": "graph",
+		"title": "Requests",
+		"id": 4,
+		"gridPos": {"h": 8, "w": 12, "x": 0, "y": 0},
+		"libraryPanel": {"uid": "abc", "name": "Requests"}
+	}`)
+	expected := `{"title":"Requests","type":"graph"}`
+	if got != expected {
+		t.Fatalf("expected %s, got %s", expected, got)
+	}
+}
+
+func testAccLibraryPanelCheckExists(rn string, panel *gapi.LibraryPanel) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("resource id not set")
+		}
+
+		client := testAccProvider.Meta().(*client).gapi
+		gotPanel, err := client.LibraryPanel(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error getting library panel: %s", err)
+		}
+
+		*panel = *gotPanel
+
+		return nil
+	}
+}
+
+func testAccLibraryPanelCheckDestroy(panel *gapi.LibraryPanel) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*client).gapi
+		_, err := client.LibraryPanel(panel.Uid)
+		if err == nil {
+			return fmt.Errorf("library panel still exists")
+		}
+		return nil
+	}
+}
+
+const testAccLibraryPanelConfig_basic = `
+resource "grafana_library_panel" "test" {
+    uid        = "tf-acc-test-panel"
+    name       = "Terraform Acceptance Test Panel"
+    model_json = <<EOT
+{
+    "type": "text",
+    "title": "Terraform Acceptance Test Panel",
+    "options": {"content": "Managed by Terraform"}
+}
+EOT
+}
+`
+
+const testAccLibraryPanelConfig_update = `
+resource "grafana_library_panel" "test" {
+    uid        = "tf-acc-test-panel"
+    name       = "Terraform Acceptance Test Panel Updated"
+    model_json = <<EOT
+{
+    "type": "text",
+    "title": "Terraform Acceptance Test Panel Updated",
+    "options": {"content": "Still managed by Terraform"}
+}
+EOT
+}
+`
@@ -0,0 +1,122 @@
+package grafana
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func ResourceMessageTemplate() *schema.Resource {
+	return &schema.Resource{
+		Create: CreateMessageTemplate,
+		Read:   ReadMessageTemplate,
+		Update: UpdateMessageTemplate,
+		Delete: DeleteMessageTemplate,
+		Importer: &schema.ResourceImporter{
+			State: importOrgResource,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org_id":             orgIDSchema(),
+			"disable_provenance": disableProvenanceSchema(),
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"template": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: suppressMessageTemplateDiff,
+			},
+		},
+	}
+}
+
+func CreateMessageTemplate(d *schema.ResourceData, meta interface{}) error {
+	if err := meta.(*client).requireVersion("grafana_message_template", "9.1.0"); err != nil {
+		return err
+	}
+
+	client, err := alertingClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+	_, err = client.MessageTemplate(name)
+	if err == nil {
+		return fmt.Errorf("Message template %q already exists: import it to manage it", name)
+	}
+	if !isNotFound(err) {
+		return accessError(err, fmt.Sprintf("reading message template %s", name))
+	}
+
+	if err := client.SetMessageTemplate(name, d.Get("template").(string)); err != nil {
+		return accessError(err, fmt.Sprintf("creating message template %s", name))
+	}
+
+	setOrgResourceID(d, name)
+
+	return ReadMessageTemplate(d, meta)
+}
+
+func UpdateMessageTemplate(d *schema.ResourceData, meta interface{}) error {
+	client, err := alertingClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	if err := client.SetMessageTemplate(orgResourceID(d), d.Get("template").(string)); err != nil {
+		return accessError(err, fmt.Sprintf("updating message template %s", orgResourceID(d)))
+	}
+
+	return ReadMessageTemplate(d, meta)
+}
+
+func ReadMessageTemplate(d *schema.ResourceData, meta interface{}) error {
+	client, err := alertingClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	template, err := client.MessageTemplate(orgResourceID(d))
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] removing message template %s from state because it no longer exists in grafana", orgResourceID(d))
+			d.SetId("")
+			return nil
+		}
+		return accessError(err, fmt.Sprintf("reading message template %s", orgResourceID(d)))
+	}
+
+	d.Set("name", template.Name)
+	d.Set("template", template.Template)
+	d.Set("disable_provenance", template.Provenance == "")
+
+	return nil
+}
+
+func DeleteMessageTemplate(d *schema.ResourceData, meta interface{}) error {
+	client, err := alertingClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	err = client.DeleteMessageTemplate(orgResourceID(d))
+	if err != nil && !isNotFound(err) {
+		return accessError(err, fmt.Sprintf("deleting message template %s", orgResourceID(d)))
+	}
+
+	return nil
+}
+
+// suppressMessageTemplateDiff ignores the whitespace around templates, which
+// Grafana trims.
+func suppressMessageTemplateDiff(k, old, new string, d *schema.ResourceData) bool {
+	return strings.TrimSpace(old) == strings.TrimSpace(new)
+}
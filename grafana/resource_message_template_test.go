@@ -0,0 +1,139 @@
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gapi "github.com/nytm/go-grafana-api"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccMessageTemplate_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccMessageTemplateCheckDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccMessageTemplateConfig("Alerts firing"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccMessageTemplateCheckExists("grafana_message_template.test"),
+					resource.TestCheckResourceAttr("grafana_message_template.test", "name", "terraform-acc-test"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccMessageTemplateConfig("Alerts still firing"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccMessageTemplateCheckExists("grafana_message_template.test"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "grafana_message_template.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestCreateMessageTemplate_disableProvenance(t *testing.T) {
+	if !ResourceMessageTemplate().Schema["disable_provenance"].ForceNew {
+		t.Fatalf("expected changing disable_provenance to replace the message template")
+	}
+
+	var saved *gapi.MessageTemplate
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/provisioning/templates/alerts" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case "GET":
+			if saved == nil {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			json.NewEncoder(w).Encode(saved)
+		case "PUT":
+			saved = &gapi.MessageTemplate{Name: "alerts", Provenance: "api"}
+			if r.Header.Get("X-Disable-Provenance") == "true" {
+				saved.Provenance = ""
+			}
+			if err := json.NewDecoder(r.Body).Decode(saved); err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			json.NewEncoder(w).Encode(saved)
+		}
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	for _, disable := range []bool{false, true} {
+		saved = nil
+		d := schema.TestResourceDataRaw(t, ResourceMessageTemplate().Schema, map[string]interface{}{
+			"name":               "alerts",
+			"template":           `{{ define "alerts" }}{{ len .Alerts }} alerts{{ end }}`,
+			"disable_provenance": disable,
+		})
+		if err := CreateMessageTemplate(d, c); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		if (saved.Provenance == "") != disable {
+			t.Fatalf("expected the X-Disable-Provenance header to be sent only when disable_provenance is set, got provenance %q with disable_provenance %t", saved.Provenance, disable)
+		}
+		if d.Get("disable_provenance").(bool) != disable {
+			t.Fatalf("expected disable_provenance to be read back as %t", disable)
+		}
+	}
+}
+
+func testAccMessageTemplateCheckExists(rn string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		client := testAccProvider.Meta().(*client).gapi
+		if _, err := client.MessageTemplate(rs.Primary.ID); err != nil {
+			return fmt.Errorf("error getting message template: %s", err)
+		}
+
+		return nil
+	}
+}
+
+func testAccMessageTemplateCheckDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*client).gapi
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "grafana_message_template" {
+			continue
+		}
+		if _, err := client.MessageTemplate(rs.Primary.ID); err == nil {
+			return fmt.Errorf("message template %s still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccMessageTemplateConfig(title string) string {
+	return fmt.Sprintf(`
+resource "grafana_message_template" "test" {
+    name     = "terraform-acc-test"
+    template = <<EOT
+
+{{ define "terraform-acc-test.title" }}%s: {{ .CommonLabels.alertname }}{{ end }}
+
+EOT
+}
+`, title)
+}
@@ -0,0 +1,221 @@
+package grafana
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	gapi "github.com/nytm/go-grafana-api"
+)
+
+var muteTimingTimePattern = regexp.MustCompile(`^(?:[01]\d|2[0-3]):[0-5]\d$|^24:00$`)
+
+func ResourceMuteTiming() *schema.Resource {
+	return &schema.Resource{
+		Create: CreateMuteTiming,
+		Read:   ReadMuteTiming,
+		Update: UpdateMuteTiming,
+		Delete: DeleteMuteTiming,
+		Importer: &schema.ResourceImporter{
+			State: importOrgResource,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org_id":             orgIDSchema(),
+			"disable_provenance": disableProvenanceSchema(),
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"intervals": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"times": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"start": &schema.Schema{
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validateMuteTimingTime,
+									},
+
+									"end": &schema.Schema{
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validateMuteTimingTime,
+									},
+								},
+							},
+						},
+
+						"weekdays": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"days_of_month": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"months": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"years": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"location": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func CreateMuteTiming(d *schema.ResourceData, meta interface{}) error {
+	if err := meta.(*client).requireVersion("grafana_mute_timing", "9.1.0"); err != nil {
+		return err
+	}
+
+	client, err := alertingClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	timing := makeMuteTiming(d)
+	if err := client.NewMuteTiming(timing); err != nil {
+		return accessError(err, fmt.Sprintf("creating mute timing %s", timing.Name))
+	}
+
+	setOrgResourceID(d, timing.Name)
+
+	return ReadMuteTiming(d, meta)
+}
+
+func UpdateMuteTiming(d *schema.ResourceData, meta interface{}) error {
+	client, err := alertingClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	if err := client.UpdateMuteTiming(makeMuteTiming(d)); err != nil {
+		return accessError(err, fmt.Sprintf("updating mute timing %s", orgResourceID(d)))
+	}
+
+	return ReadMuteTiming(d, meta)
+}
+
+func ReadMuteTiming(d *schema.ResourceData, meta interface{}) error {
+	client, err := alertingClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	timing, err := client.MuteTiming(orgResourceID(d))
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] removing mute timing %s from state because it no longer exists in grafana", orgResourceID(d))
+			d.SetId("")
+			return nil
+		}
+		return accessError(err, fmt.Sprintf("reading mute timing %s", orgResourceID(d)))
+	}
+
+	intervals := []interface{}{}
+	for _, interval := range timing.TimeIntervals {
+		times := []interface{}{}
+		for _, t := range interval.Times {
+			times = append(times, map[string]interface{}{
+				"start": t.StartTime,
+				"end":   t.EndTime,
+			})
+		}
+		intervals = append(intervals, map[string]interface{}{
+			"times":         times,
+			"weekdays":      interval.Weekdays,
+			"days_of_month": interval.DaysOfMonth,
+			"months":        interval.Months,
+			"years":         interval.Years,
+			"location":      interval.Location,
+		})
+	}
+
+	d.Set("name", timing.Name)
+	d.Set("disable_provenance", timing.Provenance == "")
+	if err := d.Set("intervals", intervals); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func DeleteMuteTiming(d *schema.ResourceData, meta interface{}) error {
+	client, err := alertingClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	err = client.DeleteMuteTiming(orgResourceID(d))
+	if err != nil && !isNotFound(err) {
+		return accessError(err, fmt.Sprintf("deleting mute timing %s", orgResourceID(d)))
+	}
+
+	return nil
+}
+
+func makeMuteTiming(d *schema.ResourceData) *gapi.MuteTiming {
+	timing := &gapi.MuteTiming{
+		Name:          d.Get("name").(string),
+		TimeIntervals: []gapi.TimeInterval{},
+	}
+	for _, interval := range d.Get("intervals").([]interface{}) {
+		// An empty intervals block matches all times.
+		if interval == nil {
+			timing.TimeIntervals = append(timing.TimeIntervals, gapi.TimeInterval{})
+			continue
+		}
+		interval := interval.(map[string]interface{})
+		timeInterval := gapi.TimeInterval{
+			Weekdays:    stringList(interval["weekdays"].([]interface{})),
+			DaysOfMonth: stringList(interval["days_of_month"].([]interface{})),
+			Months:      stringList(interval["months"].([]interface{})),
+			Years:       stringList(interval["years"].([]interface{})),
+			Location:    interval["location"].(string),
+		}
+		for _, t := range interval["times"].([]interface{}) {
+			t := t.(map[string]interface{})
+			timeInterval.Times = append(timeInterval.Times, gapi.TimeRange{
+				StartTime: t["start"].(string),
+				EndTime:   t["end"].(string),
+			})
+		}
+		timing.TimeIntervals = append(timing.TimeIntervals, timeInterval)
+	}
+	return timing
+}
+
+func validateMuteTimingTime(v interface{}, k string) ([]string, []error) {
+	if !muteTimingTimePattern.MatchString(v.(string)) {
+		return nil, []error{fmt.Errorf("%q must be a time of day as HH:MM, got %q", k, v)}
+	}
+	return nil, nil
+}
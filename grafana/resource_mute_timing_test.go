@@ -0,0 +1,152 @@
+package grafana
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	gapi "github.com/nytm/go-grafana-api"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccMuteTiming_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccMuteTimingCheckDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccMuteTimingConfig("saturday:sunday"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccMuteTimingCheckExists("grafana_mute_timing.test"),
+					resource.TestCheckResourceAttr("grafana_mute_timing.test", "intervals.0.weekdays.0", "saturday:sunday"),
+					resource.TestCheckResourceAttr("grafana_mute_timing.test", "intervals.0.times.0.start", "00:00"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccMuteTimingConfig("friday:sunday"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_mute_timing.test", "intervals.0.weekdays.0", "friday:sunday"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "grafana_mute_timing.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestMakeMuteTiming(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, ResourceMuteTiming().Schema, map[string]interface{}{
+		"name": "maintenance",
+		"intervals": []interface{}{
+			map[string]interface{}{
+				"times": []interface{}{
+					map[string]interface{}{"start": "02:00", "end": "04:00"},
+				},
+				"weekdays":      []interface{}{"tuesday"},
+				"days_of_month": []interface{}{"1:7"},
+				"location":      "Europe/Paris",
+			},
+		},
+	})
+
+	expected := &gapi.MuteTiming{
+		Name: "maintenance",
+		TimeIntervals: []gapi.TimeInterval{
+			{
+				Times:       []gapi.TimeRange{{StartTime: "02:00", EndTime: "04:00"}},
+				Weekdays:    []string{"tuesday"},
+				DaysOfMonth: []string{"1:7"},
+				Location:    "Europe/Paris",
+			},
+		},
+	}
+	if timing := makeMuteTiming(d); !reflect.DeepEqual(timing, expected) {
+		t.Fatalf("expected mute timing %#v, got %#v", expected, timing)
+	}
+}
+
+func TestMakeMuteTiming_emptyInterval(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, ResourceMuteTiming().Schema, map[string]interface{}{
+		"name":      "always",
+		"intervals": []interface{}{map[string]interface{}{}},
+	})
+
+	expected := &gapi.MuteTiming{
+		Name:          "always",
+		TimeIntervals: []gapi.TimeInterval{{}},
+	}
+	if timing := makeMuteTiming(d); !reflect.DeepEqual(timing, expected) {
+		t.Fatalf("expected mute timing %#v, got %#v", expected, timing)
+	}
+}
+
+func TestValidateMuteTimingTime(t *testing.T) {
+	for value, ok := range map[string]bool{
+		"00:00": true,
+		"09:30": true,
+		"24:00": true,
+		"24:30": false,
+		"9:30":  false,
+		"12:60": false,
+	} {
+		_, errs := validateMuteTimingTime(value, "start")
+		if ok != (len(errs) == 0) {
+			t.Errorf("%q: expected valid to be %t, got %v", value, ok, errs)
+		}
+	}
+}
+
+func testAccMuteTimingCheckExists(rn string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		client := testAccProvider.Meta().(*client).gapi
+		if _, err := client.MuteTiming(rs.Primary.ID); err != nil {
+			return fmt.Errorf("error getting mute timing: %s", err)
+		}
+
+		return nil
+	}
+}
+
+func testAccMuteTimingCheckDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*client).gapi
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "grafana_mute_timing" {
+			continue
+		}
+		if _, err := client.MuteTiming(rs.Primary.ID); err == nil {
+			return fmt.Errorf("mute timing %s still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccMuteTimingConfig(weekdays string) string {
+	return fmt.Sprintf(`
+resource "grafana_mute_timing" "test" {
+    name = "terraform-acc-test"
+
+    intervals {
+        times {
+            start = "00:00"
+            end   = "06:00"
+        }
+
+        weekdays = ["%s"]
+        months   = ["1:3", "december"]
+        location = "UTC"
+    }
+}
+`, weekdays)
+}
@@ -0,0 +1,120 @@
+package grafana
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	gapi "github.com/nytm/go-grafana-api"
+)
+
+func ResourceNgalertAdminConfig() *schema.Resource {
+	return &schema.Resource{
+		Create: UpdateNgalertAdminConfig,
+		Read:   ReadNgalertAdminConfig,
+		Update: UpdateNgalertAdminConfig,
+		Delete: DeleteNgalertAdminConfig,
+		Importer: &schema.ResourceImporter{
+			State: ImportNgalertAdminConfig,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDSchema(),
+
+			"alertmanagers_choice": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "all",
+				ValidateFunc: validateStringIn("all", "internal", "external"),
+			},
+
+			"external_alertmanagers": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// UpdateNgalertAdminConfig sets the alerting configuration of the
+// organization, which always has one.
+func UpdateNgalertAdminConfig(d *schema.ResourceData, meta interface{}) error {
+	if err := meta.(*client).requireVersion("grafana_ngalert_admin_config", "9.2.0"); err != nil {
+		return err
+	}
+
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	config := &gapi.NgalertAdminConfig{
+		AlertmanagersChoice: d.Get("alertmanagers_choice").(string),
+	}
+	if err := client.SetNgalertAdminConfig(config); err != nil {
+		return accessError(err, "updating the alerting configuration")
+	}
+
+	setOrgResourceID(d, "admin_config")
+
+	return ReadNgalertAdminConfig(d, meta)
+}
+
+func ReadNgalertAdminConfig(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	// Grafana has no configuration until one is set, and then uses its
+	// default.
+	choice := "all"
+	config, err := client.NgalertAdminConfig()
+	if err != nil && !isNotFound(err) {
+		return accessError(err, "reading the alerting configuration")
+	}
+	if err == nil && config.AlertmanagersChoice != "" {
+		choice = config.AlertmanagersChoice
+	}
+
+	alertmanagers, err := client.ExternalAlertmanagers()
+	if err != nil {
+		return accessError(err, "reading the external Alertmanagers")
+	}
+	urls := []string{}
+	for _, alertmanager := range alertmanagers.Active {
+		urls = append(urls, alertmanager.Url)
+	}
+
+	d.Set("alertmanagers_choice", choice)
+	if err := d.Set("external_alertmanagers", urls); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteNgalertAdminConfig resets the alerting configuration of the
+// organization to Grafana's default.
+func DeleteNgalertAdminConfig(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	if err := client.DeleteNgalertAdminConfig(); err != nil && !isNotFound(err) {
+		return accessError(err, "resetting the alerting configuration")
+	}
+
+	return nil
+}
+
+// ImportNgalertAdminConfig imports the alerting configuration of the
+// organization. Since an organization has a single configuration, any ID
+// imports it, optionally prefixed with the ID of the organization, e.g.
+// 2:admin_config.
+func ImportNgalertAdminConfig(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	orgID, _ := splitOrgResourceID(d.Id())
+	d.Set("org_id", int(orgID))
+	setOrgResourceID(d, "admin_config")
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,123 @@
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gapi "github.com/nytm/go-grafana-api"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccNgalertAdminConfig_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccNgalertAdminConfigCheckChoice("all"),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccNgalertAdminConfigConfig("internal"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccNgalertAdminConfigCheckChoice("internal"),
+					resource.TestCheckResourceAttr("grafana_ngalert_admin_config.test", "external_alertmanagers.#", "0"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccNgalertAdminConfigConfig("all"),
+				Check:  testAccNgalertAdminConfigCheckChoice("all"),
+			},
+			resource.TestStep{
+				ResourceName:      "grafana_ngalert_admin_config.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestNgalertAdminConfig_lifecycle(t *testing.T) {
+	var config *gapi.NgalertAdminConfig
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Grafana-Org-Id") != "2" {
+			t.Errorf("expected the request to be scoped to organization 2, got %q", r.Header.Get("X-Grafana-Org-Id"))
+		}
+		switch {
+		case r.URL.Path == "/api/v1/ngalert/alertmanagers":
+			w.Write([]byte(`{"status": "success", "data": {"activeAlertManagers": [{"url": "http://mimir:8080/alertmanager/api/v2/alerts"}], "droppedAlertManagers": []}}`))
+		case r.URL.Path != "/api/v1/ngalert/admin_config":
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == "GET" && config == nil:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "no admin configuration available"}`))
+		case r.Method == "GET":
+			json.NewEncoder(w).Encode(config)
+		case r.Method == "POST":
+			config = &gapi.NgalertAdminConfig{}
+			if err := json.NewDecoder(r.Body).Decode(config); err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == "DELETE":
+			config = nil
+		}
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	d := schema.TestResourceDataRaw(t, ResourceNgalertAdminConfig().Schema, map[string]interface{}{
+		"org_id":               2,
+		"alertmanagers_choice": "external",
+	})
+	if err := UpdateNgalertAdminConfig(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Id() != "2:admin_config" || config.AlertmanagersChoice != "external" {
+		t.Fatalf("expected the configuration of organization 2 to be set, got %q and %#v", d.Id(), config)
+	}
+	if urls := d.Get("external_alertmanagers").([]interface{}); len(urls) != 1 || urls[0] != "http://mimir:8080/alertmanager/api/v2/alerts" {
+		t.Fatalf("expected the external Alertmanager to be read, got %v", urls)
+	}
+
+	if err := DeleteNgalertAdminConfig(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := ReadNgalertAdminConfig(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if choice := d.Get("alertmanagers_choice").(string); choice != "all" {
+		t.Fatalf("expected Grafana's default to be read without a configuration, got %q", choice)
+	}
+}
+
+func testAccNgalertAdminConfigCheckChoice(choice string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*client).gapi
+		config, err := client.NgalertAdminConfig()
+		if err != nil && !isNotFound(err) {
+			return fmt.Errorf("error getting the alerting configuration: %s", err)
+		}
+		got := "all"
+		if err == nil && config.AlertmanagersChoice != "" {
+			got = config.AlertmanagersChoice
+		}
+		if got != choice {
+			return fmt.Errorf("expected alerts to be sent to %s Alertmanagers, got %s", choice, got)
+		}
+		return nil
+	}
+}
+
+func testAccNgalertAdminConfigConfig(choice string) string {
+	return fmt.Sprintf(`
+resource "grafana_ngalert_admin_config" "test" {
+    alertmanagers_choice = "%s"
+}
+`, choice)
+}
@@ -0,0 +1,255 @@
+package grafana
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	gapi "github.com/nytm/go-grafana-api"
+)
+
+// notificationPolicyDepth is how deep policies can be nested, since schemas
+// can't be recursive.
+const notificationPolicyDepth = 4
+
+func ResourceNotificationPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: UpdateNotificationPolicy,
+		Read:   ReadNotificationPolicy,
+		Update: UpdateNotificationPolicy,
+		Delete: DeleteNotificationPolicy,
+		Importer: &schema.ResourceImporter{
+			State: ImportNotificationPolicy,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org_id":             orgIDSchema(),
+			"disable_provenance": disableProvenanceSchema(),
+
+			"contact_point": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"group_by": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"group_wait":      alertingDurationSchema(),
+			"group_interval":  alertingDurationSchema(),
+			"repeat_interval": alertingDurationSchema(),
+
+			"policy": notificationPolicySchema(notificationPolicyDepth),
+		},
+	}
+}
+
+// notificationPolicySchema is the schema of nested policies, which can
+// themselves have nested policies down to the given depth.
+func notificationPolicySchema(depth int) *schema.Schema {
+	s := map[string]*schema.Schema{
+		"contact_point": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+
+		"group_by": &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+
+		"matcher": &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"label": &schema.Schema{
+						Type:     schema.TypeString,
+						Required: true,
+					},
+
+					"match": &schema.Schema{
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validateStringIn("=", "!=", "=~", "!~"),
+					},
+
+					"value": &schema.Schema{
+						Type:     schema.TypeString,
+						Required: true,
+					},
+				},
+			},
+		},
+
+		"continue": &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"mute_timings": &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+
+		"group_wait":      alertingDurationSchema(),
+		"group_interval":  alertingDurationSchema(),
+		"repeat_interval": alertingDurationSchema(),
+	}
+	if depth > 1 {
+		s["policy"] = notificationPolicySchema(depth - 1)
+	}
+
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: s,
+		},
+	}
+}
+
+// UpdateNotificationPolicy replaces the whole notification policy tree of
+// the organization.
+func UpdateNotificationPolicy(d *schema.ResourceData, meta interface{}) error {
+	if err := meta.(*client).requireVersion("grafana_notification_policy", "9.1.0"); err != nil {
+		return err
+	}
+
+	client, err := alertingClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	tree := &gapi.NotificationPolicy{
+		Receiver:       d.Get("contact_point").(string),
+		GroupBy:        stringList(d.Get("group_by").([]interface{})),
+		GroupWait:      d.Get("group_wait").(string),
+		GroupInterval:  d.Get("group_interval").(string),
+		RepeatInterval: d.Get("repeat_interval").(string),
+		Routes:         makeNotificationPolicies(d.Get("policy").([]interface{})),
+	}
+	if err := client.SetNotificationPolicyTree(tree); err != nil {
+		return accessError(err, "updating the notification policy tree")
+	}
+
+	setOrgResourceID(d, "policy")
+
+	return ReadNotificationPolicy(d, meta)
+}
+
+func ReadNotificationPolicy(d *schema.ResourceData, meta interface{}) error {
+	client, err := alertingClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	tree, err := client.NotificationPolicyTree()
+	if err != nil {
+		return accessError(err, "reading the notification policy tree")
+	}
+
+	d.Set("contact_point", tree.Receiver)
+	d.Set("group_by", tree.GroupBy)
+	d.Set("group_wait", tree.GroupWait)
+	d.Set("group_interval", tree.GroupInterval)
+	d.Set("repeat_interval", tree.RepeatInterval)
+	d.Set("disable_provenance", tree.Provenance == "")
+	if err := d.Set("policy", readNotificationPolicies(tree.Routes, notificationPolicyDepth)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ImportNotificationPolicy imports the notification policy tree of the
+// organization. Since an organization has a single tree, any ID imports it,
+// optionally prefixed with the ID of the organization, e.g. 2:policy.
+func ImportNotificationPolicy(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	orgID, _ := splitOrgResourceID(d.Id())
+	d.Set("org_id", int(orgID))
+	setOrgResourceID(d, "policy")
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// DeleteNotificationPolicy resets the notification policy tree to the
+// default one, since an organization always has one.
+func DeleteNotificationPolicy(d *schema.ResourceData, meta interface{}) error {
+	client, err := alertingClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	if err := client.ResetNotificationPolicyTree(); err != nil {
+		return accessError(err, "resetting the notification policy tree")
+	}
+
+	return nil
+}
+
+func makeNotificationPolicies(blocks []interface{}) []gapi.NotificationPolicy {
+	var policies []gapi.NotificationPolicy
+	for _, block := range blocks {
+		block := block.(map[string]interface{})
+		policy := gapi.NotificationPolicy{
+			Receiver:          block["contact_point"].(string),
+			GroupBy:           stringList(block["group_by"].([]interface{})),
+			MuteTimeIntervals: stringList(block["mute_timings"].([]interface{})),
+			Continue:          block["continue"].(bool),
+			GroupWait:         block["group_wait"].(string),
+			GroupInterval:     block["group_interval"].(string),
+			RepeatInterval:    block["repeat_interval"].(string),
+		}
+		for _, matcher := range block["matcher"].([]interface{}) {
+			matcher := matcher.(map[string]interface{})
+			policy.ObjectMatchers = append(policy.ObjectMatchers, [3]string{
+				matcher["label"].(string),
+				matcher["match"].(string),
+				matcher["value"].(string),
+			})
+		}
+		if nested, ok := block["policy"]; ok {
+			policy.Routes = makeNotificationPolicies(nested.([]interface{}))
+		}
+		policies = append(policies, policy)
+	}
+	return policies
+}
+
+// readNotificationPolicies reads nested policies back down to the given
+// depth. Policies nested deeper, e.g. in Grafana's web UI, are left out.
+func readNotificationPolicies(policies []gapi.NotificationPolicy, depth int) []interface{} {
+	blocks := []interface{}{}
+	for _, policy := range policies {
+		var matchers []interface{}
+		for _, matcher := range policy.ObjectMatchers {
+			matchers = append(matchers, map[string]interface{}{
+				"label": matcher[0],
+				"match": matcher[1],
+				"value": matcher[2],
+			})
+		}
+		block := map[string]interface{}{
+			"contact_point":   policy.Receiver,
+			"group_by":        policy.GroupBy,
+			"matcher":         matchers,
+			"continue":        policy.Continue,
+			"mute_timings":    policy.MuteTimeIntervals,
+			"group_wait":      policy.GroupWait,
+			"group_interval":  policy.GroupInterval,
+			"repeat_interval": policy.RepeatInterval,
+		}
+		if depth > 1 {
+			block["policy"] = readNotificationPolicies(policy.Routes, depth-1)
+		} else if len(policy.Routes) > 0 {
+			log.Printf("[WARN] leaving out the policies nested in the notification policy tree deeper than %d levels", notificationPolicyDepth)
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
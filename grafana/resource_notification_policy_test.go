@@ -0,0 +1,192 @@
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	gapi "github.com/nytm/go-grafana-api"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccNotificationPolicy_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccNotificationPolicyCheckDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccNotificationPolicyConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccNotificationPolicyCheckReceiver("terraform-acc-test"),
+					resource.TestCheckResourceAttr("grafana_notification_policy.test", "policy.#", "1"),
+					resource.TestCheckResourceAttr("grafana_notification_policy.test", "policy.0.matcher.0.label", "team"),
+					resource.TestCheckResourceAttr("grafana_notification_policy.test", "policy.0.policy.#", "1"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "grafana_notification_policy.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestUpdateNotificationPolicy(t *testing.T) {
+	var tree gapi.NotificationPolicy
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/frontend/settings":
+			w.Write([]byte(`{"buildInfo": {"version": "10.0.0"}}`))
+		case r.Method == "PUT" && r.URL.Path == "/api/v1/provisioning/policies":
+			if err := json.NewDecoder(r.Body).Decode(&tree); err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			w.WriteHeader(http.StatusAccepted)
+		case r.Method == "GET" && r.URL.Path == "/api/v1/provisioning/policies":
+			returned := tree
+			// Grafana returns durations in its own format.
+			returned.GroupWait = "1m"
+			json.NewEncoder(w).Encode(returned)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	d := schema.TestResourceDataRaw(t, ResourceNotificationPolicy().Schema, map[string]interface{}{
+		"contact_point": "default",
+		"group_by":      []interface{}{"alertname"},
+		"group_wait":    "60s",
+		"policy": []interface{}{
+			map[string]interface{}{
+				"contact_point": "ops",
+				"matcher": []interface{}{
+					map[string]interface{}{"label": "team", "match": "=", "value": "ops"},
+				},
+				"policy": []interface{}{
+					map[string]interface{}{
+						"contact_point": "pager",
+						"continue":      true,
+						"mute_timings":  []interface{}{"weekends"},
+					},
+				},
+			},
+		},
+	})
+	if err := UpdateNotificationPolicy(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := []gapi.NotificationPolicy{
+		{
+			Receiver:       "ops",
+			ObjectMatchers: [][3]string{{"team", "=", "ops"}},
+			Routes: []gapi.NotificationPolicy{
+				{Receiver: "pager", Continue: true, MuteTimeIntervals: []string{"weekends"}},
+			},
+		},
+	}
+	if !reflect.DeepEqual(tree.Routes, expected) {
+		t.Fatalf("expected policies %#v, got %#v", expected, tree.Routes)
+	}
+	if mute := d.Get("policy.0.policy.0.mute_timings.0").(string); mute != "weekends" {
+		t.Fatalf("expected the nested policy to be read back, got %q", mute)
+	}
+	if !suppressAlertingDurationDiff("group_wait", d.Get("group_wait").(string), "60s", d) {
+		t.Fatalf("expected %s to be the same duration as 60s", d.Get("group_wait"))
+	}
+}
+
+func TestParseAlertingDuration(t *testing.T) {
+	for s, expected := range map[string]string{
+		"30s":   "30s",
+		"1h30m": "1h30m0s",
+		"1d":    "24h0m0s",
+		"1w":    "168h0m0s",
+		"500ms": "500ms",
+	} {
+		duration, err := parseAlertingDuration(s)
+		if err != nil || duration.String() != expected {
+			t.Errorf("%s: expected %s, got %s (%v)", s, expected, duration, err)
+		}
+	}
+	for _, s := range []string{"", "1x", "m5", "1.5h"} {
+		if _, err := parseAlertingDuration(s); err == nil {
+			t.Errorf("%q: expected an error", s)
+		}
+	}
+}
+
+func testAccNotificationPolicyCheckReceiver(receiver string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*client).gapi
+		tree, err := client.NotificationPolicyTree()
+		if err != nil {
+			return fmt.Errorf("error getting notification policy tree: %s", err)
+		}
+		if tree.Receiver != receiver {
+			return fmt.Errorf("expected contact point %s, got %s", receiver, tree.Receiver)
+		}
+		return nil
+	}
+}
+
+func testAccNotificationPolicyCheckDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*client).gapi
+	tree, err := client.NotificationPolicyTree()
+	if err != nil {
+		return fmt.Errorf("error getting notification policy tree: %s", err)
+	}
+	if len(tree.Routes) > 0 {
+		return fmt.Errorf("notification policy tree wasn't reset")
+	}
+	return nil
+}
+
+const testAccNotificationPolicyConfig_basic = `
+resource "grafana_contact_point" "test" {
+    name = "terraform-acc-test"
+
+    email {
+        addresses = ["one@example.net"]
+    }
+}
+
+resource "grafana_notification_policy" "test" {
+    contact_point   = "${grafana_contact_point.test.name}"
+    group_by        = ["alertname"]
+    group_wait      = "45s"
+    repeat_interval = "3h"
+
+    policy {
+        contact_point = "${grafana_contact_point.test.name}"
+
+        matcher {
+            label = "team"
+            match = "="
+            value = "ops"
+        }
+
+        policy {
+            group_by = ["cluster"]
+
+            matcher {
+                label = "severity"
+                match = "=~"
+                value = "critical|page"
+            }
+        }
+    }
+}
+`
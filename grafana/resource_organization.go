@@ -0,0 +1,729 @@
+package grafana
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform/helper/schema"
+	gapi "github.com/nytm/go-grafana-api"
+)
+
+// defaultAdminUser is the login of the server admin Grafana creates on
+// first start, which it also makes an admin of every organization it
+// creates through the API.
+const defaultAdminUser = "admin"
+
+// defaultOrgID is the ID of the organization Grafana creates on first
+// start, which single-organization setups can't do without.
+const defaultOrgID = 1
+
+// orgRoles lists the attributes holding the members of an organization
+// along with the role they are given.
+var orgRoles = []struct {
+	attribute string
+	role      string
+}{
+	{"admins", "Admin"},
+	{"editors", "Editor"},
+	{"viewers", "Viewer"},
+	{"users_without_access", "None"},
+}
+
+func ResourceOrganization() *schema.Resource {
+	return &schema.Resource{
+		Create: CreateOrganization,
+		Read:   ReadOrganization,
+		Update: UpdateOrganization,
+		Delete: DeleteOrganization,
+		Exists: ExistsOrganization,
+		Importer: &schema.ResourceImporter{
+			State: ImportOrganization,
+		},
+
+		SchemaVersion: 1,
+		MigrateState:  resourceOrganizationMigrateState,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"admin_user": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       defaultAdminUser,
+				Deprecated:    "Use admin_users instead",
+				ConflictsWith: []string{"admin_users"},
+			},
+
+			"admin_users": &schema.Schema{
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				Set:           schema.HashString,
+				ConflictsWith: []string{"admin_user"},
+			},
+
+			"allow_default_org_deletion": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"manage_users": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"ignore_externally_synced_users": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"ignore_users": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateOrgUser,
+				},
+				Set: schema.HashString,
+			},
+
+			"create_users": &schema.Schema{
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"strict_users"},
+			},
+
+			"strict_users": &schema.Schema{
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"create_users"},
+			},
+
+			"org_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"admins": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateOrgUser,
+				},
+				Set:              schema.HashString,
+				DiffSuppressFunc: suppressUnmanagedUsersDiff,
+			},
+
+			"editors": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateOrgUser,
+				},
+				Set:              schema.HashString,
+				DiffSuppressFunc: suppressUnmanagedUsersDiff,
+			},
+
+			"viewers": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateOrgUser,
+				},
+				Set:              schema.HashString,
+				DiffSuppressFunc: suppressUnmanagedUsersDiff,
+			},
+
+			"users_without_access": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateOrgUser,
+				},
+				Set:              schema.HashString,
+				DiffSuppressFunc: suppressUnmanagedUsersDiff,
+			},
+		},
+	}
+}
+
+func CreateOrganization(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*client).forOrg(0)
+	if err != nil {
+		return err
+	}
+
+	id, err := client.NewOrg(d.Get("name").(string))
+	if err != nil {
+		return accessError(err, "creating organization")
+	}
+
+	d.SetId(strconv.FormatInt(id, 10))
+
+	if err := UpdateUsers(d, meta); err != nil {
+		return err
+	}
+
+	return ReadOrganization(d, meta)
+}
+
+func ReadOrganization(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*client).forOrg(0)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Invalid id: %#v", d.Id())
+	}
+
+	org, err := client.Org(id)
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] removing organization %s from state because it no longer exists in grafana", d.Get("name").(string))
+			d.SetId("")
+			return nil
+		}
+		return accessError(err, fmt.Sprintf("reading organization %s", d.Id()))
+	}
+
+	d.Set("name", org.Name)
+	d.Set("org_id", org.Id)
+
+	return ReadUsers(d, meta)
+}
+
+func UpdateOrganization(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*client).forOrg(0)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Invalid id: %#v", d.Id())
+	}
+
+	if d.HasChange("name") {
+		if err := client.UpdateOrg(id, d.Get("name").(string)); err != nil {
+			return accessError(err, fmt.Sprintf("updating organization %s", d.Id()))
+		}
+	}
+
+	if err := UpdateUsers(d, meta); err != nil {
+		return err
+	}
+
+	return ReadOrganization(d, meta)
+}
+
+func DeleteOrganization(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*client).forOrg(0)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Invalid id: %#v", d.Id())
+	}
+
+	if id == defaultOrgID && !d.Get("allow_default_org_deletion").(bool) {
+		return fmt.Errorf("Refusing to delete the default organization %q (ID %d), which Grafana needs unless other organizations are set up; set allow_default_org_deletion to delete it anyway, or remove it from state with terraform state rm to stop managing it", d.Get("name").(string), id)
+	}
+
+	err = client.DeleteOrg(id)
+	if err != nil && !isNotFound(err) {
+		return accessError(err, fmt.Sprintf("deleting organization %s", d.Id()))
+	}
+
+	return nil
+}
+
+func ExistsOrganization(d *schema.ResourceData, meta interface{}) (bool, error) {
+	client, err := meta.(*client).forOrg(0)
+	if err != nil {
+		return false, err
+	}
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return false, fmt.Errorf("Invalid id: %#v", d.Id())
+	}
+
+	_, err = client.Org(id)
+	if err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, accessError(err, fmt.Sprintf("reading organization %s", d.Id()))
+	}
+
+	return true, nil
+}
+
+// ImportOrganization imports an organization by its ID or by its name.
+func ImportOrganization(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client, err := meta.(*client).forOrg(0)
+	if err != nil {
+		return nil, err
+	}
+
+	var org *gapi.Org
+	if id, parseErr := strconv.ParseInt(d.Id(), 10, 64); parseErr == nil {
+		org, err = client.Org(id)
+	} else {
+		org, err = client.OrgByName(d.Id())
+	}
+	if err != nil {
+		if isNotFound(err) {
+			return nil, fmt.Errorf("Organization %q not found", d.Id())
+		}
+		return nil, accessError(err, fmt.Sprintf("importing organization %s", d.Id()))
+	}
+
+	d.SetId(strconv.FormatInt(org.Id, 10))
+	// Defaults aren't applied to imported resources: without an admin user
+	// the server admin would be read as a managed member, and membership
+	// wouldn't be read at all.
+	d.Set("admin_user", defaultAdminUser)
+	d.Set("manage_users", true)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// ReadUsers reads the members of an organization into its role attributes,
+// leaving out the admin users and ignored users. Nothing is read when the
+// organization's membership isn't managed, so plans never show it.
+func ReadUsers(d *schema.ResourceData, meta interface{}) error {
+	if !d.Get("manage_users").(bool) {
+		return nil
+	}
+
+	client, err := meta.(*client).forOrg(0)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Invalid id: %#v", d.Id())
+	}
+
+	orgUsers, err := client.OrgUsers(id)
+	if err != nil {
+		return accessError(err, fmt.Sprintf("reading users of organization %s", d.Id()))
+	}
+
+	// Members are listed by login when that's how the role attributes
+	// refer to them, or when they have no email, and by email otherwise.
+	// Grafana doesn't preserve the case of emails on every auth path, so
+	// members keep the spelling they are listed with.
+	listed := map[string]string{}
+	for _, r := range orgRoles {
+		for _, user := range d.Get(r.attribute).(*schema.Set).List() {
+			listed[strings.ToLower(user.(string))] = user.(string)
+		}
+	}
+
+	usersByRole := map[string][]string{}
+	for _, orgUser := range orgUsers {
+		if ignoredUser(d, orgUser) {
+			continue
+		}
+		user := orgUser.Email
+		if spelling, ok := listed[strings.ToLower(orgUser.Login)]; ok {
+			user = spelling
+		} else if spelling, ok := listed[strings.ToLower(orgUser.Email)]; ok {
+			user = spelling
+		} else if user == "" {
+			user = orgUser.Login
+		}
+		usersByRole[orgUser.Role] = append(usersByRole[orgUser.Role], user)
+	}
+
+	for _, r := range orgRoles {
+		d.Set(r.attribute, usersByRole[r.role])
+	}
+
+	return nil
+}
+
+// suppressUnmanagedUsersDiff ignores changes to the role attributes of an
+// organization whose membership isn't managed, since they aren't applied.
+func suppressUnmanagedUsersDiff(k, old, new string, d *schema.ResourceData) bool {
+	return !d.Get("manage_users").(bool)
+}
+
+// adminUsers returns the logins of the admin users of an organization,
+// which are never managed by Terraform.
+func adminUsers(d *schema.ResourceData) []string {
+	var logins []string
+	for _, login := range d.Get("admin_users").(*schema.Set).List() {
+		logins = append(logins, login.(string))
+	}
+	if len(logins) == 0 {
+		logins = append(logins, d.Get("admin_user").(string))
+	}
+	return logins
+}
+
+// ignoredUser reports whether a member of an organization is left out of
+// its role attributes: the admin users, users that membership is ignored
+// for and, if asked for, users whose membership is synced from an external
+// auth provider such as LDAP or SAML.
+func ignoredUser(d *schema.ResourceData, orgUser gapi.OrgUser) bool {
+	for _, login := range adminUsers(d) {
+		if strings.EqualFold(login, orgUser.Login) {
+			return true
+		}
+	}
+	if orgUser.IsExternallySynced && d.Get("ignore_externally_synced_users").(bool) {
+		return true
+	}
+	for _, user := range d.Get("ignore_users").(*schema.Set).List() {
+		if strings.EqualFold(user.(string), orgUser.Login) || strings.EqualFold(user.(string), orgUser.Email) {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateUsers adds, updates and removes the members of an organization so
+// that they match its role attributes, unless its membership isn't managed.
+func UpdateUsers(d *schema.ResourceData, meta interface{}) error {
+	if !d.Get("manage_users").(bool) {
+		return nil
+	}
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Invalid id: %#v", d.Id())
+	}
+
+	if err := checkDuplicateUsers(d); err != nil {
+		return err
+	}
+
+	add, update, remove := userDiff(userMap(d))
+
+	if d.Get("users_without_access").(*schema.Set).Len() > 0 {
+		if err := meta.(*client).requireVersion("users_without_access", "10.0.0"); err != nil {
+			return err
+		}
+	}
+
+	if d.Get("strict_users").(bool) {
+		if err := checkUsersExist(meta, add, update); err != nil {
+			return err
+		}
+	}
+
+	// Every change is attempted even if others fail, and the membership
+	// that was actually applied is saved to state, so that running again
+	// only retries the failed changes.
+	var result *multierror.Error
+	if err := addUsers(meta, id, add, d.Get("create_users").(bool)); err != nil {
+		result = multierror.Append(result, err)
+	}
+	if err := updateUsers(meta, id, update); err != nil {
+		result = multierror.Append(result, err)
+	}
+	if err := removeUsers(meta, id, remove); err != nil {
+		result = multierror.Append(result, err)
+	}
+
+	if err := result.ErrorOrNil(); err != nil {
+		if readErr := ReadUsers(d, meta); readErr != nil {
+			log.Printf("[WARN] failed to read the users of organization %s back: %s", d.Id(), readErr)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// checkDuplicateUsers fails if a user is listed in more than one of the
+// role attributes of an organization, as it's unclear which role they
+// should get. The SDK doesn't allow checking this at plan time, so it's
+// checked before any membership is changed instead.
+func checkDuplicateUsers(d *schema.ResourceData) error {
+	listedIn := map[string]string{}
+	for _, r := range orgRoles {
+		for _, user := range d.Get(r.attribute).(*schema.Set).List() {
+			key := strings.ToLower(user.(string))
+			if attribute, ok := listedIn[key]; ok {
+				return fmt.Errorf("User %s is listed in both %s and %s", user, attribute, r.attribute)
+			}
+			listedIn[key] = r.attribute
+		}
+	}
+	return nil
+}
+
+// validateOrgUser checks that an organization member is given by an email
+// or login that could exist in Grafana.
+func validateOrgUser(v interface{}, k string) ([]string, []error) {
+	user := v.(string)
+	if user == "" {
+		return nil, []error{fmt.Errorf("%q must not contain empty users", k)}
+	}
+	if strings.TrimSpace(user) != user || strings.ContainsAny(user, " \t\n") {
+		return nil, []error{fmt.Errorf("%q must not contain whitespace, got %q", k, user)}
+	}
+	if i := strings.Index(user, "@"); i >= 0 {
+		if i == 0 || i == len(user)-1 || strings.Count(user, "@") > 1 {
+			return nil, []error{fmt.Errorf("%q must contain valid emails or logins, got %q", k, user)}
+		}
+	}
+	return nil, nil
+}
+
+// userChange is a change to the role a user has in an organization.
+type userChange struct {
+	user string
+	role string
+}
+
+// userMap returns the users listed in the role attributes of an
+// organization along with their roles, both as currently in state and as
+// configured. Users are keyed by the lowercased login or email they are
+// listed by, as Grafana compares those case-insensitively. Ignored users
+// are left out.
+func userMap(d *schema.ResourceData) (map[string]userChange, map[string]userChange) {
+	ignored := map[string]bool{}
+	for _, user := range d.Get("ignore_users").(*schema.Set).List() {
+		ignored[strings.ToLower(user.(string))] = true
+	}
+
+	stateUsers, configUsers := map[string]userChange{}, map[string]userChange{}
+	for _, r := range orgRoles {
+		state, config := d.GetChange(r.attribute)
+		for _, user := range state.(*schema.Set).List() {
+			if key := strings.ToLower(user.(string)); !ignored[key] {
+				stateUsers[key] = userChange{user.(string), r.role}
+			}
+		}
+		for _, user := range config.(*schema.Set).List() {
+			if key := strings.ToLower(user.(string)); !ignored[key] {
+				configUsers[key] = userChange{user.(string), r.role}
+			}
+		}
+	}
+	return stateUsers, configUsers
+}
+
+// userDiff returns the users to add to an organization, the users whose
+// role must be updated, and the users to remove from it, sorted by name.
+func userDiff(stateUsers, configUsers map[string]userChange) (add, update, remove []userChange) {
+	for key, configUser := range configUsers {
+		stateUser, ok := stateUsers[key]
+		switch {
+		case !ok:
+			add = append(add, configUser)
+		case stateUser.role != configUser.role:
+			update = append(update, configUser)
+		}
+	}
+	for key, stateUser := range stateUsers {
+		if _, ok := configUsers[key]; !ok {
+			remove = append(remove, stateUser)
+		}
+	}
+
+	for _, changes := range [][]userChange{add, update, remove} {
+		sort.Slice(changes, func(i, j int) bool { return changes[i].user < changes[j].user })
+	}
+	return add, update, remove
+}
+
+// checkUsersExist fails, naming the culprits, if any of the users to be
+// added to or updated in an organization don't exist in Grafana.
+func checkUsersExist(meta interface{}, changes ...[]userChange) error {
+	userIDs, err := meta.(*client).userIDs()
+	if err != nil {
+		return accessError(err, "listing users")
+	}
+
+	var missing []string
+	for _, c := range changes {
+		for _, change := range c {
+			if _, ok := userIDs[strings.ToLower(change.user)]; !ok {
+				missing = append(missing, change.user)
+			}
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("Users not found in grafana: %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+func addUsers(meta interface{}, orgID int64, changes []userChange, create bool) error {
+	c := meta.(*client)
+	client, err := c.forOrg(0)
+	if err != nil {
+		return err
+	}
+	userIDs, err := c.userIDs()
+	if err != nil {
+		return accessError(err, "listing users")
+	}
+
+	var created int32
+	defer func() {
+		if atomic.LoadInt32(&created) > 0 {
+			c.invalidateUsers()
+		}
+	}()
+
+	return applyUserChanges(changes, func(change userChange) error {
+		if _, ok := userIDs[strings.ToLower(change.user)]; !ok {
+			if !create {
+				log.Printf("[WARN] not adding user %s to organization %d because they don't exist in grafana", change.user, orgID)
+				return nil
+			}
+			if err := createUser(client, change.user); err != nil {
+				return actionError(err, fmt.Sprintf("creating user %s", change.user))
+			}
+			atomic.StoreInt32(&created, 1)
+		}
+		if err := client.AddOrgUser(orgID, change.user, change.role); err != nil {
+			return actionError(err, fmt.Sprintf("adding user %s to organization %d", change.user, orgID))
+		}
+		return nil
+	})
+}
+
+// createUser creates a Grafana user with the given email or login, and a
+// random password: the user is expected to log in through an external auth
+// provider, or to have their password reset.
+func createUser(client *gapi.Client, user string) error {
+	password, err := uuid.GenerateUUID()
+	if err != nil {
+		return err
+	}
+
+	form := dtos.AdminCreateUserForm{Name: user, Password: password}
+	if strings.Contains(user, "@") {
+		form.Email = user
+	} else {
+		form.Login = user
+	}
+
+	return client.CreateUserForm(form)
+}
+
+func updateUsers(meta interface{}, orgID int64, changes []userChange) error {
+	c := meta.(*client)
+	client, err := c.forOrg(0)
+	if err != nil {
+		return err
+	}
+	userIDs, err := c.userIDs()
+	if err != nil {
+		return accessError(err, "listing users")
+	}
+
+	return applyUserChanges(changes, func(change userChange) error {
+		userID, ok := userIDs[strings.ToLower(change.user)]
+		if !ok {
+			log.Printf("[WARN] not updating user %s in organization %d because they don't exist in grafana", change.user, orgID)
+			return nil
+		}
+		if err := client.UpdateOrgUser(orgID, userID, change.role); err != nil {
+			return actionError(err, fmt.Sprintf("updating user %s in organization %d", change.user, orgID))
+		}
+		return nil
+	})
+}
+
+func removeUsers(meta interface{}, orgID int64, changes []userChange) error {
+	c := meta.(*client)
+	client, err := c.forOrg(0)
+	if err != nil {
+		return err
+	}
+	userIDs, err := c.userIDs()
+	if err != nil {
+		return accessError(err, "listing users")
+	}
+
+	return applyUserChanges(changes, func(change userChange) error {
+		userID, ok := userIDs[strings.ToLower(change.user)]
+		if !ok {
+			log.Printf("[WARN] not removing user %s from organization %d because they don't exist in grafana", change.user, orgID)
+			return nil
+		}
+		err := client.RemoveOrgUser(orgID, userID)
+		if err != nil && !isNotFound(err) {
+			return actionError(err, fmt.Sprintf("removing user %s from organization %d", change.user, orgID))
+		}
+		return nil
+	})
+}
+
+// userChangeWorkers is the number of membership changes applied to an
+// organization at the same time. The provider's max_parallel_requests
+// bounds them further, if set.
+const userChangeWorkers = 10
+
+// applyUserChanges calls apply for every change, from a bounded pool of
+// goroutines so that large organizations are set up quickly, and returns
+// the errors of all failed changes.
+func applyUserChanges(changes []userChange, apply func(userChange) error) error {
+	work := make(chan userChange)
+	var mu sync.Mutex
+	var errs []error
+
+	var wg sync.WaitGroup
+	for i := 0; i < userChangeWorkers && i < len(changes); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for change := range work {
+				if err := apply(change); err != nil {
+					mu.Lock()
+					errs = append(errs, err)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for _, change := range changes {
+		work <- change
+	}
+	close(work)
+	wg.Wait()
+
+	if len(errs) == 0 {
+		return nil
+	}
+	// Report errors in a stable order, whatever order changes completed in.
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Error() < errs[j].Error() })
+	return multierror.Append(nil, errs...)
+}
@@ -0,0 +1,58 @@
+package grafana
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func resourceOrganizationMigrateState(v int, is *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
+	switch v {
+	case 0:
+		log.Println("[INFO] Found Grafana Organization State v0; migrating to v1")
+		return migrateOrganizationStateV0toV1(is)
+	default:
+		return is, fmt.Errorf("Unexpected schema version: %d", v)
+	}
+}
+
+// migrateOrganizationStateV0toV1 turns the role attributes from lists into
+// sets, which are keyed by the hash of their elements rather than by index.
+func migrateOrganizationStateV0toV1(is *terraform.InstanceState) (*terraform.InstanceState, error) {
+	if is.Empty() {
+		log.Println("[DEBUG] Empty InstanceState; nothing to migrate.")
+		return is, nil
+	}
+
+	log.Printf("[DEBUG] Attributes before migration: %#v", is.Attributes)
+
+	for _, r := range orgRoles {
+		prefix := r.attribute + "."
+
+		users := map[string]bool{}
+		for k, v := range is.Attributes {
+			if strings.HasPrefix(k, prefix) {
+				if k != prefix+"#" {
+					users[v] = true
+				}
+				delete(is.Attributes, k)
+			}
+		}
+
+		if len(users) == 0 {
+			continue
+		}
+		for user := range users {
+			is.Attributes[prefix+strconv.Itoa(schema.HashString(user))] = user
+		}
+		// Users listed more than once are only kept once.
+		is.Attributes[prefix+"#"] = strconv.Itoa(len(users))
+	}
+
+	log.Printf("[DEBUG] Attributes after migration: %#v", is.Attributes)
+	return is, nil
+}
@@ -0,0 +1,96 @@
+package grafana
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestOrganizationMigrateState(t *testing.T) {
+	hash := func(user string) string {
+		return strconv.Itoa(schema.HashString(user))
+	}
+
+	cases := map[string]struct {
+		StateVersion int
+		Attributes   map[string]string
+		Expected     map[string]string
+	}{
+		"v0_1_roles": {
+			StateVersion: 0,
+			Attributes: map[string]string{
+				"name":       "Test Organization",
+				"admin_user": "admin",
+				"admins.#":   "1",
+				"admins.0":   "admin@example.com",
+				"editors.#":  "2",
+				"editors.0":  "editor-02@example.com",
+				"editors.1":  "editor-01@example.com",
+				"viewers.#":  "3",
+				"viewers.0":  "viewer@example.com",
+				"viewers.1":  "sso-user",
+				"viewers.2":  "viewer@example.com",
+			},
+			Expected: map[string]string{
+				"name":                                "Test Organization",
+				"admin_user":                          "admin",
+				"admins.#":                            "1",
+				"admins." + hash("admin@example.com"): "admin@example.com",
+				"editors.#":                           "2",
+				"editors." + hash("editor-01@example.com"): "editor-01@example.com",
+				"editors." + hash("editor-02@example.com"): "editor-02@example.com",
+				"viewers.#":                             "2",
+				"viewers." + hash("viewer@example.com"): "viewer@example.com",
+				"viewers." + hash("sso-user"):           "sso-user",
+			},
+		},
+		"v0_1_no_members": {
+			StateVersion: 0,
+			Attributes: map[string]string{
+				"name":      "Test Organization",
+				"admins.#":  "0",
+				"editors.#": "0",
+			},
+			Expected: map[string]string{
+				"name": "Test Organization",
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		is := &terraform.InstanceState{
+			ID:         "2",
+			Attributes: tc.Attributes,
+		}
+		is, err := resourceOrganizationMigrateState(tc.StateVersion, is, nil)
+		if err != nil {
+			t.Fatalf("bad: %s, err: %#v", tn, err)
+		}
+
+		if !reflect.DeepEqual(is.Attributes, tc.Expected) {
+			t.Fatalf("bad: %s\n\n expected: %#v\n got: %#v", tn, tc.Expected, is.Attributes)
+		}
+	}
+}
+
+func TestOrganizationMigrateState_empty(t *testing.T) {
+	var is *terraform.InstanceState
+
+	// should handle nil
+	is, err := resourceOrganizationMigrateState(0, is, nil)
+	if err != nil {
+		t.Fatalf("err: %#v", err)
+	}
+	if is != nil {
+		t.Fatalf("expected nil instancestate, got: %#v", is)
+	}
+
+	// should handle non-nil but empty
+	is = &terraform.InstanceState{}
+	if _, err := resourceOrganizationMigrateState(0, is, nil); err != nil {
+		t.Fatalf("err: %#v", err)
+	}
+}
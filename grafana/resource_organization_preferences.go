@@ -0,0 +1,155 @@
+package grafana
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	gapi "github.com/nytm/go-grafana-api"
+)
+
+func ResourceOrganizationPreferences() *schema.Resource {
+	return &schema.Resource{
+		Create: CreateOrganizationPreferences,
+		Read:   ReadOrganizationPreferences,
+		Update: UpdateOrganizationPreferences,
+		Delete: DeleteOrganizationPreferences,
+		Importer: &schema.ResourceImporter{
+			State: ImportOrganizationPreferences,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDSchema(),
+
+			"theme": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateStringIn("", "light", "dark", "system"),
+			},
+
+			"home_dashboard_uid": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"timezone": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"week_start": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateStringIn("", "saturday", "sunday", "monday"),
+			},
+		},
+	}
+}
+
+func CreateOrganizationPreferences(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	org, err := client.CurrentOrg()
+	if err != nil {
+		return accessError(err, "reading organization")
+	}
+
+	if err := updateOrganizationPreferences(d, meta); err != nil {
+		return err
+	}
+
+	d.SetId(strconv.FormatInt(org.Id, 10))
+
+	return ReadOrganizationPreferences(d, meta)
+}
+
+func ReadOrganizationPreferences(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	prefs, err := client.OrgPreferences()
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] removing preferences of organization %s from state because it no longer exists in grafana", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return accessError(err, fmt.Sprintf("reading preferences of organization %s", d.Id()))
+	}
+
+	d.Set("theme", prefs.Theme)
+	d.Set("home_dashboard_uid", prefs.HomeDashboardUID)
+	d.Set("timezone", prefs.Timezone)
+	d.Set("week_start", prefs.WeekStart)
+
+	return nil
+}
+
+func UpdateOrganizationPreferences(d *schema.ResourceData, meta interface{}) error {
+	if err := updateOrganizationPreferences(d, meta); err != nil {
+		return err
+	}
+
+	return ReadOrganizationPreferences(d, meta)
+}
+
+// DeleteOrganizationPreferences resets the preferences of the organization
+// to Grafana's defaults.
+func DeleteOrganizationPreferences(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	err = client.UpdateOrgPreferences(gapi.Preferences{})
+	if err != nil && !isNotFound(err) {
+		return accessError(err, fmt.Sprintf("resetting preferences of organization %s", d.Id()))
+	}
+
+	return nil
+}
+
+// ImportOrganizationPreferences imports the preferences of an organization
+// by the organization's ID.
+func ImportOrganizationPreferences(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	orgID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid organization id: %#v", d.Id())
+	}
+
+	d.Set("org_id", orgID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func updateOrganizationPreferences(d *schema.ResourceData, meta interface{}) error {
+	prefs := gapi.Preferences{
+		Theme:            d.Get("theme").(string),
+		HomeDashboardUID: d.Get("home_dashboard_uid").(string),
+		Timezone:         d.Get("timezone").(string),
+		WeekStart:        d.Get("week_start").(string),
+	}
+
+	if prefs.HomeDashboardUID != "" {
+		if err := meta.(*client).requireVersion("home_dashboard_uid", "9.0.0"); err != nil {
+			return err
+		}
+	}
+
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	if err := client.UpdateOrgPreferences(prefs); err != nil {
+		return accessError(err, "updating organization preferences")
+	}
+
+	return nil
+}
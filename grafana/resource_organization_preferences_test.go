@@ -0,0 +1,90 @@
+package grafana
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	gapi "github.com/nytm/go-grafana-api"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccOrganizationPreferences_basic(t *testing.T) {
+	var org gapi.Org
+	var prefs gapi.Preferences
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccOrganizationCheckDestroy(&org),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccOrganizationPreferencesConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccOrganizationCheckExists("grafana_organization.test", &org),
+					testAccOrganizationPreferencesCheckExists("grafana_organization_preferences.test", &prefs),
+					resource.TestCheckResourceAttr(
+						"grafana_organization_preferences.test", "theme", "dark",
+					),
+					resource.TestCheckResourceAttr(
+						"grafana_organization_preferences.test", "timezone", "utc",
+					),
+					resource.TestCheckResourceAttr(
+						"grafana_organization_preferences.test", "week_start", "monday",
+					),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "grafana_organization_preferences.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccOrganizationPreferencesCheckExists(rn string, prefs *gapi.Preferences) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("resource id not set")
+		}
+
+		orgID, err := strconv.ParseInt(rs.Primary.ID, 10, 64)
+		if err != nil {
+			return fmt.Errorf("resource id is malformed")
+		}
+
+		client, err := testAccProvider.Meta().(*client).forOrg(orgID)
+		if err != nil {
+			return err
+		}
+		gotPrefs, err := client.OrgPreferences()
+		if err != nil {
+			return fmt.Errorf("error getting organization preferences: %s", err)
+		}
+
+		*prefs = *gotPrefs
+
+		return nil
+	}
+}
+
+const testAccOrganizationPreferencesConfig_basic = `
+resource "grafana_organization" "test" {
+    name = "terraform-acc-test-preferences"
+}
+
+resource "grafana_organization_preferences" "test" {
+    org_id     = "${grafana_organization.test.org_id}"
+    theme      = "dark"
+    timezone   = "utc"
+    week_start = "monday"
+}
+`
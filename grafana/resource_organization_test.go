@@ -0,0 +1,498 @@
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/hashicorp/go-multierror"
+	gapi "github.com/nytm/go-grafana-api"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccOrganization_basic(t *testing.T) {
+	var org gapi.Org
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccOrganizationCheckDestroy(&org),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccOrganizationConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccOrganizationCheckExists("grafana_organization.test", &org),
+					resource.TestCheckResourceAttr(
+						"grafana_organization.test", "name", "terraform-acc-test",
+					),
+					resource.TestCheckResourceAttrSet(
+						"grafana_organization.test", "org_id",
+					),
+				),
+			},
+			resource.TestStep{
+				Config: testAccOrganizationConfig_renamed,
+				Check: resource.ComposeTestCheckFunc(
+					testAccOrganizationCheckExists("grafana_organization.test", &org),
+					resource.TestCheckResourceAttr(
+						"grafana_organization.test", "name", "terraform-acc-test-renamed",
+					),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOrganization_import(t *testing.T) {
+	var org gapi.Org
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccOrganizationCheckDestroy(&org),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccOrganizationConfig_basic,
+				Check:  testAccOrganizationCheckExists("grafana_organization.test", &org),
+			},
+			resource.TestStep{
+				ResourceName:      "grafana_organization.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			resource.TestStep{
+				ResourceName:      "grafana_organization.test",
+				ImportState:       true,
+				ImportStateId:     "terraform-acc-test",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestUserDiff(t *testing.T) {
+	stateUsers := map[string]userChange{
+		"kept@example.com":     {"Kept@Example.com", "Viewer"},
+		"promoted@example.com": {"promoted@example.com", "Viewer"},
+		"removed@example.com":  {"removed@example.com", "Editor"},
+	}
+	configUsers := map[string]userChange{
+		"kept@example.com":     {"kept@example.com", "Viewer"},
+		"promoted@example.com": {"Promoted@Example.com", "Admin"},
+		"added-b@example.com":  {"added-b@example.com", "Editor"},
+		"added-a@example.com":  {"added-a@example.com", "Viewer"},
+	}
+
+	add, update, remove := userDiff(stateUsers, configUsers)
+
+	expectedAdd := []userChange{{"added-a@example.com", "Viewer"}, {"added-b@example.com", "Editor"}}
+	if !reflect.DeepEqual(add, expectedAdd) {
+		t.Errorf("expected users to add %v, got %v", expectedAdd, add)
+	}
+	expectedUpdate := []userChange{{"Promoted@Example.com", "Admin"}}
+	if !reflect.DeepEqual(update, expectedUpdate) {
+		t.Errorf("expected users to update %v, got %v", expectedUpdate, update)
+	}
+	expectedRemove := []userChange{{"removed@example.com", "Editor"}}
+	if !reflect.DeepEqual(remove, expectedRemove) {
+		t.Errorf("expected users to remove %v, got %v", expectedRemove, remove)
+	}
+}
+
+func TestCreateUser(t *testing.T) {
+	var got dtos.AdminCreateUserForm
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/admin/users" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"id": 2, "message": "User created"}`))
+	}))
+	defer server.Close()
+
+	client, err := gapi.New("admin:admin", server.URL)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if err := createUser(client, "user@example.com"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if got.Email != "user@example.com" || got.Login != "" || got.Password == "" {
+		t.Errorf("unexpected user %#v", got)
+	}
+
+	if err := createUser(client, "sso-user"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if got.Login != "sso-user" || got.Email != "" || got.Password == "" {
+		t.Errorf("unexpected user %#v", got)
+	}
+}
+
+func TestCheckUsersExist(t *testing.T) {
+	c := &client{userIDsByName: map[string]int64{"user@example.com": 2, "sso-user": 3}}
+
+	if err := checkUsersExist(c, []userChange{{"User@Example.com", "Viewer"}}, []userChange{{"sso-user", "Admin"}}); err != nil {
+		t.Errorf("expected existing users to be accepted, got %s", err)
+	}
+
+	err := checkUsersExist(c, []userChange{{"typo@example.com", "Viewer"}, {"user@example.com", "Viewer"}}, []userChange{{"another-typo", "Admin"}})
+	if err == nil || err.Error() != "Users not found in grafana: another-typo, typo@example.com" {
+		t.Errorf("expected missing users to be named, got %v", err)
+	}
+}
+
+func TestIgnoredUser(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, ResourceOrganization().Schema, map[string]interface{}{
+		"name":                           "Test Organization",
+		"ignore_externally_synced_users": true,
+		"ignore_users":                   []interface{}{"Break-Glass@Example.com"},
+	})
+
+	cases := []struct {
+		user    gapi.OrgUser
+		ignored bool
+	}{
+		{gapi.OrgUser{Login: "admin", Email: "admin@localhost"}, true},
+		{gapi.OrgUser{Login: "ldap-user", Email: "ldap@example.com", IsExternallySynced: true}, true},
+		{gapi.OrgUser{Login: "break-glass", Email: "break-glass@example.com"}, true},
+		{gapi.OrgUser{Login: "user", Email: "user@example.com", AuthLabels: []string{"OAuth"}}, false},
+	}
+
+	for _, tc := range cases {
+		if ignored := ignoredUser(d, tc.user); ignored != tc.ignored {
+			t.Errorf("expected ignoredUser to be %t for %s, got %t", tc.ignored, tc.user.Login, ignored)
+		}
+	}
+}
+
+func TestIgnoredUser_adminUsers(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, ResourceOrganization().Schema, map[string]interface{}{
+		"name":        "Test Organization",
+		"admin_users": []interface{}{"break-glass-1", "break-glass-2"},
+	})
+
+	for login, ignored := range map[string]bool{"break-glass-1": true, "Break-Glass-2": true, "admin": false} {
+		if got := ignoredUser(d, gapi.OrgUser{Login: login}); got != ignored {
+			t.Errorf("expected ignoredUser to be %t for %s, got %t", ignored, login, got)
+		}
+	}
+}
+
+func TestUpdateUsers_partialFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/users":
+			w.Write([]byte(`[{"id": 1, "login": "admin"}, {"id": 2, "email": "ok@example.com"}, {"id": 3, "email": "broken@example.com"}]`))
+		case r.Method == "POST" && r.URL.Path == "/api/orgs/2/users":
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["loginOrEmail"] == "broken@example.com" {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(`{"message": "Could not add user to organization"}`))
+				return
+			}
+			w.Write([]byte(`{"message": "User added to organization"}`))
+		case r.Method == "GET" && r.URL.Path == "/api/orgs/2/users":
+			w.Write([]byte(`[{"userId": 1, "login": "admin", "role": "Admin"}, {"userId": 2, "email": "ok@example.com", "role": "Editor"}]`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	d := schema.TestResourceDataRaw(t, ResourceOrganization().Schema, map[string]interface{}{
+		"name":    "Test Organization",
+		"editors": []interface{}{"ok@example.com", "broken@example.com"},
+	})
+	d.SetId("2")
+
+	err := UpdateUsers(d, c)
+	if err == nil || !strings.Contains(err.Error(), "broken@example.com") {
+		t.Fatalf("expected the failure to add broken@example.com to be returned, got %v", err)
+	}
+
+	editors := d.Get("editors").(*schema.Set)
+	if editors.Len() != 1 || !editors.Contains("ok@example.com") {
+		t.Errorf("expected only the users actually added to be saved, got %v", editors.List())
+	}
+}
+
+func TestValidateOrgUser(t *testing.T) {
+	cases := map[string]bool{
+		"user@example.com":   true,
+		"sso-user":           true,
+		"":                   false,
+		" user@example.com":  false,
+		"user@example.com\n": false,
+		"first last":         false,
+		"@example.com":       false,
+		"user@":              false,
+		"user@@example.com":  false,
+	}
+
+	for value, valid := range cases {
+		_, errs := validateOrgUser(value, "admins")
+		if valid && len(errs) > 0 {
+			t.Errorf("expected %q to be valid, got %v", value, errs)
+		}
+		if !valid && len(errs) == 0 {
+			t.Errorf("expected %q to be invalid", value)
+		}
+	}
+}
+
+func TestCheckDuplicateUsers(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, ResourceOrganization().Schema, map[string]interface{}{
+		"name":    "Test Organization",
+		"admins":  []interface{}{"admin@example.com"},
+		"editors": []interface{}{"editor@example.com"},
+	})
+	if err := checkDuplicateUsers(d); err != nil {
+		t.Errorf("expected distinct users to be accepted, got %s", err)
+	}
+
+	d = schema.TestResourceDataRaw(t, ResourceOrganization().Schema, map[string]interface{}{
+		"name":    "Test Organization",
+		"admins":  []interface{}{"user@example.com"},
+		"viewers": []interface{}{"User@Example.com"},
+	})
+	err := checkDuplicateUsers(d)
+	if err == nil || !strings.Contains(err.Error(), "admins and viewers") {
+		t.Errorf("expected the duplicate user to be reported, got %v", err)
+	}
+}
+
+func TestUpdateUsers_unmanaged(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL)
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	d := schema.TestResourceDataRaw(t, ResourceOrganization().Schema, map[string]interface{}{
+		"name":         "Test Organization",
+		"manage_users": false,
+		"editors":      []interface{}{"editor@example.com"},
+	})
+	d.SetId("2")
+
+	if err := UpdateUsers(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := ReadUsers(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestOrganizationDiff_unmanagedUsers(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "2",
+		Attributes: map[string]string{
+			"name":         "Test Organization",
+			"admin_user":   "admin",
+			"manage_users": "true",
+			"editors.#":    "0",
+		},
+	}
+	for manage, changes := range map[bool]bool{true: true, false: false} {
+		raw, err := config.NewRawConfig(map[string]interface{}{
+			"name":         "Test Organization",
+			"manage_users": manage,
+			"editors":      []interface{}{"editor@example.com"},
+		})
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		diff, err := ResourceOrganization().Diff(state, terraform.NewResourceConfig(raw))
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		_, ok := diff.Attributes["editors.#"]
+		if ok != changes {
+			t.Errorf("with manage_users %t, expected a change to editors to be %t, got %v", manage, changes, diff.Attributes)
+		}
+	}
+}
+
+func TestApplyUserChanges(t *testing.T) {
+	var changes []userChange
+	for i := 0; i < 100; i++ {
+		changes = append(changes, userChange{fmt.Sprintf("user-%02d", i), "Viewer"})
+	}
+
+	var mu sync.Mutex
+	applied := map[string]bool{}
+	inFlight, maxInFlight := 0, 0
+	err := applyUserChanges(changes, func(change userChange) error {
+		mu.Lock()
+		applied[change.user] = true
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		if change.user == "user-07" || change.user == "user-42" {
+			return fmt.Errorf("failed to apply %s", change.user)
+		}
+		return nil
+	})
+
+	if len(applied) != len(changes) {
+		t.Errorf("expected all %d changes to be applied, got %d", len(changes), len(applied))
+	}
+	if maxInFlight > userChangeWorkers {
+		t.Errorf("expected at most %d changes in flight, got %d", userChangeWorkers, maxInFlight)
+	}
+	if maxInFlight < 2 {
+		t.Errorf("expected changes to be applied concurrently")
+	}
+
+	merr, ok := err.(*multierror.Error)
+	if !ok || len(merr.Errors) != 2 || merr.Errors[0].Error() != "failed to apply user-07" || merr.Errors[1].Error() != "failed to apply user-42" {
+		t.Errorf("expected both failures in order, got %v", err)
+	}
+
+	if err := applyUserChanges(nil, func(userChange) error { return nil }); err != nil {
+		t.Errorf("expected no changes to succeed, got %s", err)
+	}
+}
+
+func TestDeleteOrganization_defaultOrg(t *testing.T) {
+	deleted := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" && r.URL.Path == "/api/orgs/1" {
+			deleted = true
+			w.Write([]byte(`{"message": "Organization deleted"}`))
+			return
+		}
+		t.Errorf("unexpected request %s %s", r.Method, r.URL)
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	d := schema.TestResourceDataRaw(t, ResourceOrganization().Schema, map[string]interface{}{
+		"name": "Main Org.",
+	})
+	d.SetId("1")
+	if err := DeleteOrganization(d, c); err == nil || !strings.Contains(err.Error(), "allow_default_org_deletion") {
+		t.Fatalf("expected deleting the default organization to be refused, got %v", err)
+	}
+	if deleted {
+		t.Fatalf("expected the default organization not to be deleted")
+	}
+
+	d = schema.TestResourceDataRaw(t, ResourceOrganization().Schema, map[string]interface{}{
+		"name":                       "Main Org.",
+		"allow_default_org_deletion": true,
+	})
+	d.SetId("1")
+	if err := DeleteOrganization(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !deleted {
+		t.Fatalf("expected the default organization to be deleted when allowed")
+	}
+}
+
+func TestOrgByName_escaping(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.EscapedPath(), "/api/orgs/name/") {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(gapi.Org{Id: 2, Name: strings.TrimPrefix(r.URL.Path, "/api/orgs/name/")})
+	}))
+	defer server.Close()
+
+	client, err := gapi.New("admin:admin", server.URL)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	for _, name := range []string{"R&D/EU", "Who?", "../admin", "100% uptime"} {
+		org, err := client.OrgByName(name)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if org.Name != name {
+			t.Errorf("expected organization %q to be requested, got %q", name, org.Name)
+		}
+	}
+}
+
+func testAccOrganizationCheckExists(rn string, org *gapi.Org) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("resource id not set")
+		}
+
+		id, err := strconv.ParseInt(rs.Primary.ID, 10, 64)
+		if err != nil {
+			return fmt.Errorf("resource id is malformed")
+		}
+
+		client := testAccProvider.Meta().(*client).gapi
+		gotOrg, err := client.Org(id)
+		if err != nil {
+			return fmt.Errorf("error getting organization: %s", err)
+		}
+
+		*org = *gotOrg
+
+		return nil
+	}
+}
+
+func testAccOrganizationCheckDestroy(org *gapi.Org) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*client).gapi
+		_, err := client.Org(org.Id)
+		if err == nil {
+			return fmt.Errorf("organization still exists")
+		}
+		return nil
+	}
+}
+
+const testAccOrganizationConfig_basic = `
+resource "grafana_organization" "test" {
+    name = "terraform-acc-test"
+}
+`
+
+const testAccOrganizationConfig_renamed = `
+resource "grafana_organization" "test" {
+    name = "terraform-acc-test-renamed"
+}
+`
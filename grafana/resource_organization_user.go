@@ -0,0 +1,198 @@
+package grafana
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func ResourceOrganizationUser() *schema.Resource {
+	return &schema.Resource{
+		Create: CreateOrganizationUser,
+		Read:   ReadOrganizationUser,
+		Update: UpdateOrganizationUser,
+		Delete: DeleteOrganizationUser,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"user": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"role": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateStringIn("Admin", "Editor", "Viewer", "None"),
+			},
+
+			"user_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func CreateOrganizationUser(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*client)
+	client, err := c.forOrg(0)
+	if err != nil {
+		return err
+	}
+
+	orgID := int64(d.Get("org_id").(int))
+	user := d.Get("user").(string)
+	role := d.Get("role").(string)
+
+	if role == "None" {
+		if err := c.requireVersion("the None role", "10.0.0"); err != nil {
+			return err
+		}
+	}
+
+	userIDs, err := c.userIDs()
+	if err != nil {
+		return accessError(err, "listing users")
+	}
+	userID, ok := userIDs[strings.ToLower(user)]
+	if !ok {
+		return fmt.Errorf("User %s not found in grafana", user)
+	}
+
+	err = client.AddOrgUser(orgID, user, role)
+	if statusCode(err) == http.StatusConflict {
+		// The user already is a member: take over their membership.
+		err = client.UpdateOrgUser(orgID, userID, role)
+	}
+	if err != nil {
+		return accessError(err, fmt.Sprintf("adding user %s to organization %d", user, orgID))
+	}
+
+	d.SetId(fmt.Sprintf("%d:%d", orgID, userID))
+
+	return ReadOrganizationUser(d, meta)
+}
+
+func ReadOrganizationUser(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*client).forOrg(0)
+	if err != nil {
+		return err
+	}
+
+	orgID, userID, err := parseOrganizationUserID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	orgUsers, err := client.OrgUsers(orgID)
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] removing organization user %s from state because the organization no longer exists in grafana", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return accessError(err, fmt.Sprintf("reading users of organization %d", orgID))
+	}
+
+	for _, orgUser := range orgUsers {
+		if orgUser.UserId != userID {
+			continue
+		}
+
+		d.Set("org_id", orgID)
+		d.Set("user_id", userID)
+		d.Set("role", orgUser.Role)
+		// Imported users are known by ID only.
+		if d.Get("user").(string) == "" {
+			user := orgUser.Email
+			if user == "" {
+				user = orgUser.Login
+			}
+			d.Set("user", user)
+		}
+		return nil
+	}
+
+	log.Printf("[WARN] removing organization user %s from state because they are no longer a member of the organization", d.Id())
+	d.SetId("")
+	return nil
+}
+
+func UpdateOrganizationUser(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*client)
+	client, err := c.forOrg(0)
+	if err != nil {
+		return err
+	}
+
+	orgID, userID, err := parseOrganizationUserID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	role := d.Get("role").(string)
+	if role == "None" {
+		if err := c.requireVersion("the None role", "10.0.0"); err != nil {
+			return err
+		}
+	}
+
+	if err := client.UpdateOrgUser(orgID, userID, role); err != nil {
+		return accessError(err, fmt.Sprintf("updating organization user %s", d.Id()))
+	}
+
+	return ReadOrganizationUser(d, meta)
+}
+
+func DeleteOrganizationUser(d *schema.ResourceData, meta interface{}) error {
+	client, err := meta.(*client).forOrg(0)
+	if err != nil {
+		return err
+	}
+
+	orgID, userID, err := parseOrganizationUserID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	err = client.RemoveOrgUser(orgID, userID)
+	if err != nil && !isNotFound(err) {
+		return accessError(err, fmt.Sprintf("removing organization user %s", d.Id()))
+	}
+
+	return nil
+}
+
+// parseOrganizationUserID splits the "orgID:userID" ID of an organization
+// user.
+func parseOrganizationUserID(id string) (int64, int64, error) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("Invalid id: %#v, expected orgID:userID", id)
+	}
+
+	orgID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("Invalid id: %#v, expected orgID:userID", id)
+	}
+	userID, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("Invalid id: %#v, expected orgID:userID", id)
+	}
+
+	return orgID, userID, nil
+}
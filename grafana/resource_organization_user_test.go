@@ -0,0 +1,108 @@
+package grafana
+
+import (
+	"fmt"
+	"testing"
+
+	gapi "github.com/nytm/go-grafana-api"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccOrganizationUser_basic(t *testing.T) {
+	var org gapi.Org
+	var orgUser gapi.OrgUser
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccOrganizationCheckDestroy(&org),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccOrganizationUserConfig("Editor"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccOrganizationCheckExists("grafana_organization.test", &org),
+					testAccOrganizationUserCheckExists("grafana_organization_user.test", &orgUser),
+					resource.TestCheckResourceAttr(
+						"grafana_organization_user.test", "role", "Editor",
+					),
+				),
+			},
+			resource.TestStep{
+				Config: testAccOrganizationUserConfig("Viewer"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccOrganizationUserCheckExists("grafana_organization_user.test", &orgUser),
+					resource.TestCheckResourceAttr(
+						"grafana_organization_user.test", "role", "Viewer",
+					),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "grafana_organization_user.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestParseOrganizationUserID(t *testing.T) {
+	orgID, userID, err := parseOrganizationUserID("2:15")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if orgID != 2 || userID != 15 {
+		t.Fatalf("expected organization 2 and user 15, got %d and %d", orgID, userID)
+	}
+
+	for _, id := range []string{"", "2", "2:", "2:user@example.com", "org:15", "2:15:1"} {
+		if _, _, err := parseOrganizationUserID(id); err == nil {
+			t.Errorf("expected %q to be invalid", id)
+		}
+	}
+}
+
+func testAccOrganizationUserCheckExists(rn string, orgUser *gapi.OrgUser) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		orgID, userID, err := parseOrganizationUserID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		client := testAccProvider.Meta().(*client).gapi
+		orgUsers, err := client.OrgUsers(orgID)
+		if err != nil {
+			return fmt.Errorf("error getting organization users: %s", err)
+		}
+
+		for _, gotOrgUser := range orgUsers {
+			if gotOrgUser.UserId == userID {
+				*orgUser = gotOrgUser
+				return nil
+			}
+		}
+
+		return fmt.Errorf("user %d is not a member of organization %d", userID, orgID)
+	}
+}
+
+func testAccOrganizationUserConfig(role string) string {
+	return fmt.Sprintf(`
+resource "grafana_organization" "test" {
+    name        = "terraform-acc-test-user"
+    ignore_users = ["admin@localhost"]
+}
+
+resource "grafana_organization_user" "test" {
+    org_id = "${grafana_organization.test.org_id}"
+    user   = "admin@localhost"
+    role   = "%s"
+}
+`, role)
+}
@@ -0,0 +1,185 @@
+package grafana
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	gapi "github.com/nytm/go-grafana-api"
+)
+
+func ResourcePlaylist() *schema.Resource {
+	return &schema.Resource{
+		Create: CreatePlaylist,
+		Read:   ReadPlaylist,
+		Update: UpdatePlaylist,
+		Delete: DeletePlaylist,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDSchema(),
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"interval": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"item": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"dashboard_uid": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"tag": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func CreatePlaylist(d *schema.ResourceData, meta interface{}) error {
+	if err := meta.(*client).requireVersion("grafana_playlist", "9.1.0"); err != nil {
+		return err
+	}
+
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	playlist, err := makePlaylist(d)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.NewPlaylist(playlist)
+	if err != nil {
+		return accessError(err, "creating playlist")
+	}
+
+	d.SetId(resp.Uid)
+
+	return ReadPlaylist(d, meta)
+}
+
+func ReadPlaylist(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	playlist, err := client.Playlist(d.Id())
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] removing playlist %s from state because it no longer exists in grafana", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return accessError(err, fmt.Sprintf("reading playlist %s", d.Id()))
+	}
+
+	sort.SliceStable(playlist.Items, func(i, j int) bool {
+		return playlist.Items[i].Order < playlist.Items[j].Order
+	})
+	items := make([]interface{}, 0, len(playlist.Items))
+	for _, item := range playlist.Items {
+		i := map[string]interface{}{
+			"dashboard_uid": "",
+			"tag":           "",
+		}
+		switch item.Type {
+		case "dashboard_by_uid":
+			i["dashboard_uid"] = item.Value
+		case "dashboard_by_tag":
+			i["tag"] = item.Value
+		default:
+			log.Printf("[WARN] ignoring item of playlist %s of unsupported type %s", d.Id(), item.Type)
+			continue
+		}
+		items = append(items, i)
+	}
+
+	d.Set("name", playlist.Name)
+	d.Set("interval", playlist.Interval)
+	d.Set("item", items)
+
+	return nil
+}
+
+func UpdatePlaylist(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	playlist, err := makePlaylist(d)
+	if err != nil {
+		return err
+	}
+
+	if err := client.UpdatePlaylist(d.Id(), playlist); err != nil {
+		return accessError(err, fmt.Sprintf("updating playlist %s", d.Id()))
+	}
+
+	return ReadPlaylist(d, meta)
+}
+
+func DeletePlaylist(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	err = client.DeletePlaylist(d.Id())
+	if err != nil && !isNotFound(err) {
+		return accessError(err, fmt.Sprintf("deleting playlist %s", d.Id()))
+	}
+
+	return nil
+}
+
+func makePlaylist(d *schema.ResourceData) (gapi.Playlist, error) {
+	playlist := gapi.Playlist{
+		Name:     d.Get("name").(string),
+		Interval: d.Get("interval").(string),
+	}
+
+	for i, item := range d.Get("item").([]interface{}) {
+		var uid, tag string
+		if item, ok := item.(map[string]interface{}); ok {
+			uid, tag = item["dashboard_uid"].(string), item["tag"].(string)
+		}
+
+		playlistItem := gapi.PlaylistItem{Order: int64(i + 1)}
+		switch {
+		case uid != "" && tag == "":
+			playlistItem.Type = "dashboard_by_uid"
+			playlistItem.Value = uid
+		case tag != "" && uid == "":
+			playlistItem.Type = "dashboard_by_tag"
+			playlistItem.Value = tag
+		default:
+			return playlist, fmt.Errorf("Each playlist item must set exactly one of dashboard_uid or tag")
+		}
+		playlist.Items = append(playlist.Items, playlistItem)
+	}
+
+	return playlist, nil
+}
@@ -0,0 +1,148 @@
+package grafana
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	gapi "github.com/nytm/go-grafana-api"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccPlaylist_basic(t *testing.T) {
+	var playlist gapi.Playlist
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccPlaylistCheckDestroy(&playlist),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccPlaylistConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccPlaylistCheckExists("grafana_playlist.test", &playlist),
+					resource.TestCheckResourceAttr("grafana_playlist.test", "interval", "5m"),
+					resource.TestCheckResourceAttr("grafana_playlist.test", "item.#", "2"),
+					resource.TestCheckResourceAttrPair(
+						"grafana_playlist.test", "item.0.dashboard_uid",
+						"grafana_dashboard.test", "uid",
+					),
+					resource.TestCheckResourceAttr("grafana_playlist.test", "item.1.tag", "wallboard"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "grafana_playlist.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestMakePlaylist(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, ResourcePlaylist().Schema, map[string]interface{}{
+		"name":     "Wallboard",
+		"interval": "5m",
+		"item": []interface{}{
+			map[string]interface{}{"tag": "wallboard"},
+			map[string]interface{}{"dashboard_uid": "abc"},
+		},
+	})
+
+	playlist, err := makePlaylist(d)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	expected := gapi.Playlist{
+		Name:     "Wallboard",
+		Interval: "5m",
+		Items: []gapi.PlaylistItem{
+			{Type: "dashboard_by_tag", Value: "wallboard", Order: 1},
+			{Type: "dashboard_by_uid", Value: "abc", Order: 2},
+		},
+	}
+	if !reflect.DeepEqual(playlist, expected) {
+		t.Fatalf("expected %v, got %v", expected, playlist)
+	}
+
+	d = schema.TestResourceDataRaw(t, ResourcePlaylist().Schema, map[string]interface{}{
+		"name":     "Wallboard",
+		"interval": "5m",
+		"item": []interface{}{
+			map[string]interface{}{"tag": "wallboard", "dashboard_uid": "abc"},
+		},
+	})
+	if _, err := makePlaylist(d); err == nil {
+		t.Fatalf("expected an error for an item with both a dashboard_uid and a tag")
+	}
+
+	d = schema.TestResourceDataRaw(t, ResourcePlaylist().Schema, map[string]interface{}{
+		"name":     "Wallboard",
+		"interval": "5m",
+		"item":     []interface{}{map[string]interface{}{}},
+	})
+	if _, err := makePlaylist(d); err == nil || !strings.Contains(err.Error(), "exactly one of dashboard_uid or tag") {
+		t.Fatalf("expected an error for an empty item, got %v", err)
+	}
+}
+
+func testAccPlaylistCheckExists(rn string, playlist *gapi.Playlist) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("resource id not set")
+		}
+
+		client := testAccProvider.Meta().(*client).gapi
+		gotPlaylist, err := client.Playlist(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error getting playlist: %s", err)
+		}
+
+		*playlist = *gotPlaylist
+
+		return nil
+	}
+}
+
+func testAccPlaylistCheckDestroy(playlist *gapi.Playlist) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*client).gapi
+		_, err := client.Playlist(playlist.Uid)
+		if err == nil {
+			return fmt.Errorf("playlist still exists")
+		}
+		return nil
+	}
+}
+
+const testAccPlaylistConfig_basic = `
+resource "grafana_dashboard" "test" {
+    config_json = <<EOT
+{
+    "title": "Terraform Acceptance Test Playlist"
+}
+EOT
+}
+
+resource "grafana_playlist" "test" {
+    name     = "Terraform Acceptance Test Playlist"
+    interval = "5m"
+
+    item {
+        dashboard_uid = "${grafana_dashboard.test.uid}"
+    }
+
+    item {
+        tag = "wallboard"
+    }
+}
+`
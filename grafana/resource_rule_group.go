@@ -0,0 +1,522 @@
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform/helper/schema"
+	gapi "github.com/nytm/go-grafana-api"
+)
+
+var metricNamePattern = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
+func ResourceRuleGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: CreateRuleGroup,
+		Read:   ReadRuleGroup,
+		Update: UpdateRuleGroup,
+		Delete: DeleteRuleGroup,
+		Importer: &schema.ResourceImporter{
+			State: ImportRuleGroup,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org_id":             orgIDSchema(),
+			"disable_provenance": disableProvenanceSchema(),
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"folder_uid": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"interval_seconds": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validateRuleGroupInterval,
+			},
+
+			"rule": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"uid": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"condition": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"record": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"metric": &schema.Schema{
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validateMetricName,
+									},
+
+									"from": &schema.Schema{
+										Type:     schema.TypeString,
+										Required: true,
+									},
+
+									"target_datasource_uid": &schema.Schema{
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+								},
+							},
+						},
+
+						"data": &schema.Schema{
+							Type:     schema.TypeList,
+							Required: true,
+							MinItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"ref_id": &schema.Schema{
+										Type:     schema.TypeString,
+										Required: true,
+									},
+
+									"datasource_uid": &schema.Schema{
+										Type:     schema.TypeString,
+										Required: true,
+									},
+
+									"query_type": &schema.Schema{
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+
+									"relative_time_range": &schema.Schema{
+										Type:     schema.TypeList,
+										Required: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"from": &schema.Schema{
+													Type:         schema.TypeInt,
+													Required:     true,
+													ValidateFunc: validateNonNegative,
+												},
+
+												"to": &schema.Schema{
+													Type:         schema.TypeInt,
+													Required:     true,
+													ValidateFunc: validateNonNegative,
+												},
+											},
+										},
+									},
+
+									"model": &schema.Schema{
+										Type:         schema.TypeString,
+										Required:     true,
+										StateFunc:    normalizeDataSourceJSON,
+										ValidateFunc: validateDataSourceJSON,
+									},
+								},
+							},
+						},
+
+						"for": &schema.Schema{
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "0s",
+							ValidateFunc:     validateAlertingDuration,
+							DiffSuppressFunc: suppressAlertingDurationDiff,
+						},
+
+						"no_data_state": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "NoData",
+							ValidateFunc: validateStringIn("NoData", "Alerting", "OK"),
+						},
+
+						"exec_err_state": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "Alerting",
+							ValidateFunc: validateStringIn("Alerting", "Error", "OK"),
+						},
+
+						"labels": &schema.Schema{
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"annotations": &schema.Schema{
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"is_paused": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func CreateRuleGroup(d *schema.ResourceData, meta interface{}) error {
+	if err := meta.(*client).requireVersion("grafana_rule_group", "9.4.0"); err != nil {
+		return err
+	}
+
+	client, err := alertingClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	folderUID, name := d.Get("folder_uid").(string), d.Get("name").(string)
+	existing, err := client.AlertRuleGroup(folderUID, name)
+	if err == nil && len(existing.Rules) > 0 {
+		return fmt.Errorf("Rule group %q already exists in folder %s: import it to manage it", name, folderUID)
+	}
+	if err != nil && !isNotFound(err) {
+		return accessError(err, fmt.Sprintf("reading rule group %s", name))
+	}
+
+	group, err := makeRuleGroup(d)
+	if err != nil {
+		return err
+	}
+	if err := requireRecordingRules(group, meta); err != nil {
+		return err
+	}
+	if err := client.SetAlertRuleGroup(group); err != nil {
+		return accessError(err, fmt.Sprintf("creating rule group %s", name))
+	}
+
+	setOrgResourceID(d, fmt.Sprintf("%s:%s", folderUID, name))
+
+	return ReadRuleGroup(d, meta)
+}
+
+func UpdateRuleGroup(d *schema.ResourceData, meta interface{}) error {
+	client, err := alertingClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	group, err := makeRuleGroup(d)
+	if err != nil {
+		return err
+	}
+	if err := requireRecordingRules(group, meta); err != nil {
+		return err
+	}
+	if err := client.SetAlertRuleGroup(group); err != nil {
+		return accessError(err, fmt.Sprintf("updating rule group %s", group.Title))
+	}
+
+	return ReadRuleGroup(d, meta)
+}
+
+func ReadRuleGroup(d *schema.ResourceData, meta interface{}) error {
+	client, err := alertingClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	folderUID, name, err := parseRuleGroupID(orgResourceID(d))
+	if err != nil {
+		return err
+	}
+
+	group, err := client.AlertRuleGroup(folderUID, name)
+	if err != nil && !isNotFound(err) {
+		return accessError(err, fmt.Sprintf("reading rule group %s", orgResourceID(d)))
+	}
+	// Grafana returns empty groups for groups without rules.
+	if err != nil || len(group.Rules) == 0 {
+		log.Printf("[WARN] removing rule group %s from state because it no longer exists in grafana", orgResourceID(d))
+		d.SetId("")
+		return nil
+	}
+
+	rules, err := readAlertRules(group.Rules)
+	if err != nil {
+		return err
+	}
+
+	d.Set("name", group.Title)
+	d.Set("folder_uid", group.FolderUid)
+	d.Set("interval_seconds", int(group.Interval))
+	d.Set("disable_provenance", group.Rules[0].Provenance == "")
+	if err := d.Set("rule", rules); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ImportRuleGroup imports a rule group by the UID of its folder and its name,
+// e.g. one built in Grafana's web UI, optionally prefixed with the ID of the
+// organization it is in, e.g. 2:folderUID:name.
+func ImportRuleGroup(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	// Rule group names may have colons, so the ID of the organization is
+	// separated by a slash, which folder UIDs can't have: 2/alerts:cpu is
+	// the group cpu of organization 2, and 12:a:b the group a:b in folder 12.
+	id := d.Id()
+	if parts := strings.SplitN(id, "/", 2); len(parts) == 2 {
+		if orgID, err := strconv.ParseInt(parts[0], 10, 64); err == nil && orgID > 0 {
+			d.Set("org_id", int(orgID))
+			id = parts[1]
+		}
+	}
+
+	folderUID, name, err := parseRuleGroupID(id)
+	if err != nil {
+		return nil, err
+	}
+	setOrgResourceID(d, id)
+
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := client.AlertRuleGroup(folderUID, name)
+	if err != nil && !isNotFound(err) {
+		return nil, accessError(err, fmt.Sprintf("importing rule group %s", orgResourceID(d)))
+	}
+	if err != nil || len(group.Rules) == 0 {
+		return nil, fmt.Errorf("Rule group %q not found in folder %s", name, folderUID)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// DeleteRuleGroup deletes the rules of the group one by one, which deletes
+// the group along with its last rule.
+func DeleteRuleGroup(d *schema.ResourceData, meta interface{}) error {
+	client, err := alertingClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	folderUID, name, err := parseRuleGroupID(orgResourceID(d))
+	if err != nil {
+		return err
+	}
+
+	group, err := client.AlertRuleGroup(folderUID, name)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return accessError(err, fmt.Sprintf("reading rule group %s", orgResourceID(d)))
+	}
+
+	var result *multierror.Error
+	for _, rule := range group.Rules {
+		if err := client.DeleteAlertRule(rule.Uid); err != nil && !isNotFound(err) {
+			result = multierror.Append(result, accessError(err, fmt.Sprintf("deleting alert rule %s (%s)", rule.Title, rule.Uid)))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+func makeRuleGroup(d *schema.ResourceData) (*gapi.AlertRuleGroup, error) {
+	group := &gapi.AlertRuleGroup{
+		Title:     d.Get("name").(string),
+		FolderUid: d.Get("folder_uid").(string),
+		Interval:  int64(d.Get("interval_seconds").(int)),
+	}
+
+	var result *multierror.Error
+	for i, r := range d.Get("rule").([]interface{}) {
+		r := r.(map[string]interface{})
+		rule := gapi.AlertRule{
+			Uid:          r["uid"].(string),
+			FolderUid:    group.FolderUid,
+			RuleGroup:    group.Title,
+			Title:        r["name"].(string),
+			Condition:    r["condition"].(string),
+			NoDataState:  r["no_data_state"].(string),
+			ExecErrState: r["exec_err_state"].(string),
+			For:          r["for"].(string),
+			Labels:       stringMap(r["labels"].(map[string]interface{})),
+			Annotations:  stringMap(r["annotations"].(map[string]interface{})),
+			IsPaused:     r["is_paused"].(bool),
+		}
+
+		refIDs := map[string]bool{}
+		for _, q := range r["data"].([]interface{}) {
+			q := q.(map[string]interface{})
+			query := gapi.AlertQuery{
+				RefId:         q["ref_id"].(string),
+				QueryType:     q["query_type"].(string),
+				DatasourceUid: q["datasource_uid"].(string),
+			}
+			if ranges := q["relative_time_range"].([]interface{}); len(ranges) > 0 {
+				timeRange := ranges[0].(map[string]interface{})
+				query.RelativeTimeRange = gapi.RelativeTimeRange{
+					From: int64(timeRange["from"].(int)),
+					To:   int64(timeRange["to"].(int)),
+				}
+			}
+			// The validate function takes care of invalid JSON.
+			json.Unmarshal([]byte(q["model"].(string)), &query.Model)
+			refIDs[query.RefId] = true
+			rule.Data = append(rule.Data, query)
+		}
+
+		if records := r["record"].([]interface{}); len(records) > 0 {
+			record := records[0].(map[string]interface{})
+			rule.Record = &gapi.AlertRecord{
+				Metric:              record["metric"].(string),
+				From:                record["from"].(string),
+				TargetDatasourceUid: record["target_datasource_uid"].(string),
+			}
+			if rule.Condition != "" {
+				result = multierror.Append(result, fmt.Errorf("rule.%d.condition can't be set on recording rule %q, which records rule.%d.record.0.from", i, rule.Title, i))
+			}
+			if !refIDs[rule.Record.From] {
+				result = multierror.Append(result, fmt.Errorf("rule.%d.record.0.from must be the ref_id of one of the data of rule %q, got %q", i, rule.Title, rule.Record.From))
+			}
+			// Grafana evaluates recording rules like alert rules, on the
+			// query they record.
+			rule.Condition = rule.Record.From
+		} else if !refIDs[rule.Condition] {
+			result = multierror.Append(result, fmt.Errorf("rule.%d.condition must be the ref_id of one of the data of rule %q, got %q", i, rule.Title, rule.Condition))
+		}
+
+		group.Rules = append(group.Rules, rule)
+	}
+
+	return group, result.ErrorOrNil()
+}
+
+// readAlertRules reads the rules of a group back, in the order Grafana keeps
+// them in.
+func readAlertRules(rules []gapi.AlertRule) ([]interface{}, error) {
+	blocks := []interface{}{}
+	for _, rule := range rules {
+		var data []interface{}
+		for _, query := range rule.Data {
+			model, err := json.Marshal(query.Model)
+			if err != nil {
+				return nil, err
+			}
+			data = append(data, map[string]interface{}{
+				"ref_id":         query.RefId,
+				"datasource_uid": query.DatasourceUid,
+				"query_type":     query.QueryType,
+				"relative_time_range": []interface{}{
+					map[string]interface{}{
+						"from": int(query.RelativeTimeRange.From),
+						"to":   int(query.RelativeTimeRange.To),
+					},
+				},
+				"model": normalizeDataSourceJSON(string(model)),
+			})
+		}
+		block := map[string]interface{}{
+			"uid":            rule.Uid,
+			"name":           rule.Title,
+			"condition":      rule.Condition,
+			"data":           data,
+			"for":            rule.For,
+			"no_data_state":  rule.NoDataState,
+			"exec_err_state": rule.ExecErrState,
+			"labels":         rule.Labels,
+			"annotations":    rule.Annotations,
+			"is_paused":      rule.IsPaused,
+		}
+		if rule.Record != nil {
+			block["record"] = []interface{}{
+				map[string]interface{}{
+					"metric":                rule.Record.Metric,
+					"from":                  rule.Record.From,
+					"target_datasource_uid": rule.Record.TargetDatasourceUid,
+				},
+			}
+			// The condition and the states of alerts don't apply to
+			// recording rules, which Grafana may return empty.
+			block["condition"] = ""
+			block["for"] = valueOr(rule.For, "0s")
+			block["no_data_state"] = valueOr(rule.NoDataState, "NoData")
+			block["exec_err_state"] = valueOr(rule.ExecErrState, "Alerting")
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
+
+// requireRecordingRules checks that Grafana supports the recording rules of
+// the group, if it has any.
+func requireRecordingRules(group *gapi.AlertRuleGroup, meta interface{}) error {
+	for _, rule := range group.Rules {
+		if rule.Record != nil {
+			return meta.(*client).requireVersion("rule.record", "11.2.0")
+		}
+	}
+	return nil
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+func parseRuleGroupID(id string) (string, string, error) {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("Invalid id: %#v, expected folderUID:name", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+// validateMetricName checks that recorded metrics have a valid Prometheus
+// metric name.
+func validateMetricName(v interface{}, k string) ([]string, []error) {
+	if !metricNamePattern.MatchString(v.(string)) {
+		return nil, []error{fmt.Errorf("%q must be a metric name of letters, digits, underscores and colons, not starting with a digit, got %q", k, v)}
+	}
+	return nil, nil
+}
+
+func validateRuleGroupInterval(v interface{}, k string) ([]string, []error) {
+	if v.(int) <= 0 {
+		return nil, []error{fmt.Errorf("%q must be positive", k)}
+	}
+	return nil, nil
+}
@@ -0,0 +1,386 @@
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gapi "github.com/nytm/go-grafana-api"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccRuleGroup_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccRuleGroupCheckDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccRuleGroupConfig("5m"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccRuleGroupCheckRules("grafana_rule_group.test", 1),
+					resource.TestCheckResourceAttr("grafana_rule_group.test", "rule.0.name", "Terraform Acceptance Test Rule"),
+					resource.TestCheckResourceAttr("grafana_rule_group.test", "rule.0.for", "5m"),
+					resource.TestCheckResourceAttrSet("grafana_rule_group.test", "rule.0.uid"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccRuleGroupConfig("10m"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccRuleGroupCheckRules("grafana_rule_group.test", 1),
+					resource.TestCheckResourceAttr("grafana_rule_group.test", "rule.0.for", "10m"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "grafana_rule_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestCreateRuleGroup(t *testing.T) {
+	var saved *gapi.AlertRuleGroup
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/frontend/settings":
+			w.Write([]byte(`{"buildInfo": {"version": "10.0.0"}}`))
+		case r.Method == "GET" && r.URL.Path == "/api/v1/provisioning/folder/alerts/rule-groups/cpu usage":
+			if saved == nil {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			json.NewEncoder(w).Encode(saved)
+		case r.Method == "PUT" && r.URL.Path == "/api/v1/provisioning/folder/alerts/rule-groups/cpu usage":
+			saved = &gapi.AlertRuleGroup{}
+			if err := json.NewDecoder(r.Body).Decode(saved); err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			for i := range saved.Rules {
+				saved.Rules[i].Uid = fmt.Sprintf("rule-%d", i+1)
+			}
+			json.NewEncoder(w).Encode(saved)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	d := schema.TestResourceDataRaw(t, ResourceRuleGroup().Schema, map[string]interface{}{
+		"name":             "cpu usage",
+		"folder_uid":       "alerts",
+		"interval_seconds": 60,
+		"rule": []interface{}{
+			map[string]interface{}{
+				"name":      "High CPU",
+				"condition": "B",
+				"for":       "5m",
+				"labels":    map[string]interface{}{"severity": "page"},
+				"data": []interface{}{
+					map[string]interface{}{
+						"ref_id":              "A",
+						"datasource_uid":      "prometheus",
+						"relative_time_range": []interface{}{map[string]interface{}{"from": 600, "to": 0}},
+						"model":               `{"refId": "A", "expr": "avg(rate(cpu_seconds_total[5m]))"}`,
+					},
+					map[string]interface{}{
+						"ref_id":              "B",
+						"datasource_uid":      "__expr__",
+						"relative_time_range": []interface{}{map[string]interface{}{"from": 0, "to": 0}},
+						"model":               `{"type": "math", "expression": "$A > 0.9"}`,
+					},
+				},
+			},
+		},
+	})
+	if err := CreateRuleGroup(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if d.Id() != "alerts:cpu usage" {
+		t.Fatalf("expected id alerts:cpu usage, got %q", d.Id())
+	}
+	rule := saved.Rules[0]
+	if saved.Interval != 60 || rule.FolderUid != "alerts" || rule.RuleGroup != "cpu usage" || rule.Labels["severity"] != "page" {
+		t.Fatalf("unexpected rule group %#v", saved)
+	}
+	if rule.Data[0].RelativeTimeRange.From != 600 || rule.Data[1].Model["expression"] != "$A > 0.9" {
+		t.Fatalf("unexpected queries %#v", rule.Data)
+	}
+	if uid := d.Get("rule.0.uid").(string); uid != "rule-1" {
+		t.Fatalf("expected the rule uid to be read back, got %q", uid)
+	}
+	if model := d.Get("rule.0.data.0.model").(string); model != `{"expr":"avg(rate(cpu_seconds_total[5m]))","refId":"A"}` {
+		t.Fatalf("expected the normalized model, got %s", model)
+	}
+}
+
+func TestMakeRuleGroup_condition(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, ResourceRuleGroup().Schema, map[string]interface{}{
+		"name":             "cpu usage",
+		"folder_uid":       "alerts",
+		"interval_seconds": 60,
+		"rule": []interface{}{
+			map[string]interface{}{
+				"name":      "High CPU",
+				"condition": "C",
+				"data": []interface{}{
+					map[string]interface{}{
+						"ref_id":              "A",
+						"datasource_uid":      "prometheus",
+						"relative_time_range": []interface{}{map[string]interface{}{"from": 600, "to": 0}},
+						"model":               `{}`,
+					},
+				},
+			},
+		},
+	})
+	if _, err := makeRuleGroup(d); err == nil || !strings.Contains(err.Error(), "rule.0.condition") {
+		t.Fatalf("expected an error for a condition that isn't a query, got %v", err)
+	}
+}
+
+func TestMakeRuleGroup_recording(t *testing.T) {
+	rule := map[string]interface{}{
+		"name": "CPU usage",
+		"record": []interface{}{
+			map[string]interface{}{
+				"metric":                "cluster:cpu_usage:rate5m",
+				"from":                  "A",
+				"target_datasource_uid": "mimir",
+			},
+		},
+		"data": []interface{}{
+			map[string]interface{}{
+				"ref_id":              "A",
+				"datasource_uid":      "prometheus",
+				"relative_time_range": []interface{}{map[string]interface{}{"from": 600, "to": 0}},
+				"model":               `{"expr": "sum(rate(node_cpu_seconds_total[5m]))"}`,
+			},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, ResourceRuleGroup().Schema, map[string]interface{}{
+		"name":             "cpu usage",
+		"folder_uid":       "alerts",
+		"interval_seconds": 60,
+		"rule":             []interface{}{rule},
+	})
+	group, err := makeRuleGroup(d)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	record := group.Rules[0].Record
+	if record == nil || record.Metric != "cluster:cpu_usage:rate5m" || record.TargetDatasourceUid != "mimir" || group.Rules[0].Condition != "A" {
+		t.Fatalf("unexpected recording rule %#v", group.Rules[0])
+	}
+
+	// Grafana returns recording rules without states.
+	group.Rules[0].NoDataState, group.Rules[0].ExecErrState, group.Rules[0].For = "", "", ""
+	blocks, err := readAlertRules(group.Rules)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	block := blocks[0].(map[string]interface{})
+	if block["condition"] != "" || block["no_data_state"] != "NoData" || block["for"] != "0s" {
+		t.Fatalf("expected the condition and states of the recording rule to match the configuration, got %v", block)
+	}
+
+	rule["condition"] = "A"
+	rule["record"].([]interface{})[0].(map[string]interface{})["from"] = "B"
+	d = schema.TestResourceDataRaw(t, ResourceRuleGroup().Schema, map[string]interface{}{
+		"name":             "cpu usage",
+		"folder_uid":       "alerts",
+		"interval_seconds": 60,
+		"rule":             []interface{}{rule},
+	})
+	_, err = makeRuleGroup(d)
+	if err == nil || !strings.Contains(err.Error(), "rule.0.condition can't be set") || !strings.Contains(err.Error(), "rule.0.record.0.from must be") {
+		t.Fatalf("expected errors for the condition and the recorded query, got %v", err)
+	}
+}
+
+func TestValidateMetricName(t *testing.T) {
+	for name, valid := range map[string]bool{
+		"cpu_usage":                true,
+		"cluster:cpu_usage:rate5m": true,
+		"_total":                   true,
+		"5m_rate":                  false,
+		"cpu-usage":                false,
+		"":                         false,
+	} {
+		_, errs := validateMetricName(name, "metric")
+		if (len(errs) == 0) != valid {
+			t.Fatalf("expected %q to be valid: %t, got %v", name, valid, errs)
+		}
+	}
+}
+
+func TestImportRuleGroup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/provisioning/folder/alerts/rule-groups/cpu":
+			w.Write([]byte(`{"title": "cpu", "folderUid": "alerts", "interval": 60, "rules": [{"uid": "rule-1", "title": "High CPU"}]}`))
+		case "/api/v1/provisioning/folder/12/rule-groups/a:b":
+			w.Write([]byte(`{"title": "a:b", "folderUid": "12", "interval": 60, "rules": [{"uid": "rule-2", "title": "Colons"}]}`))
+		case "/api/v1/provisioning/folder/alerts/rule-groups/empty":
+			w.Write([]byte(`{"title": "empty", "folderUid": "alerts", "interval": 60, "rules": []}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	for id, want := range map[string]string{
+		"alerts:cpu":     "",
+		"alerts:empty":   `Rule group "empty" not found in folder alerts`,
+		"alerts:missing": `Rule group "missing" not found in folder alerts`,
+		"cpu":            "expected folderUID:name",
+		"2/alerts:cpu":   "",
+		"12:a:b":         "",
+		"2/cpu":          "expected folderUID:name",
+	} {
+		d := ResourceRuleGroup().Data(nil)
+		d.SetId(id)
+		_, err := ImportRuleGroup(d, c)
+		if want == "" && err != nil {
+			t.Fatalf("expected %s to be imported, got %s", id, err)
+		}
+		if want != "" && (err == nil || !strings.Contains(err.Error(), want)) {
+			t.Fatalf("expected importing %s to fail with %q, got %v", id, want, err)
+		}
+	}
+
+	for id, expected := range map[string]struct {
+		orgID int
+		id    string
+	}{
+		"2/alerts:cpu": {2, "2:alerts:cpu"},
+		"12:a:b":       {0, "12:a:b"},
+	} {
+		d := ResourceRuleGroup().Data(nil)
+		d.SetId(id)
+		if _, err := ImportRuleGroup(d, c); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if d.Get("org_id").(int) != expected.orgID || d.Id() != expected.id {
+			t.Errorf("expected %s to be imported as %s in organization %d, got %s in organization %d", id, expected.id, expected.orgID, d.Id(), d.Get("org_id"))
+		}
+	}
+}
+
+func TestParseRuleGroupID(t *testing.T) {
+	folderUID, name, err := parseRuleGroupID("alerts:cpu: usage")
+	if err != nil || folderUID != "alerts" || name != "cpu: usage" {
+		t.Fatalf("expected alerts and cpu: usage, got %q, %q, %v", folderUID, name, err)
+	}
+	if _, _, err := parseRuleGroupID("alerts"); err == nil {
+		t.Fatalf("expected an error for an id without a group name")
+	}
+}
+
+func testAccRuleGroupCheckRules(rn string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+		folderUID, name, err := parseRuleGroupID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		client := testAccProvider.Meta().(*client).gapi
+		group, err := client.AlertRuleGroup(folderUID, name)
+		if err != nil {
+			return fmt.Errorf("error getting rule group: %s", err)
+		}
+		if len(group.Rules) != count {
+			return fmt.Errorf("expected %d rules, got %d", count, len(group.Rules))
+		}
+
+		return nil
+	}
+}
+
+func testAccRuleGroupCheckDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*client).gapi
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "grafana_rule_group" {
+			continue
+		}
+		folderUID, name, err := parseRuleGroupID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		group, err := client.AlertRuleGroup(folderUID, name)
+		if err == nil && len(group.Rules) > 0 {
+			return fmt.Errorf("rule group %s still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccRuleGroupConfig(duration string) string {
+	return fmt.Sprintf(`
+resource "grafana_folder" "test" {
+    title = "Terraform Acceptance Test Rule Group"
+}
+
+resource "grafana_data_source" "test" {
+    uid  = "tf-acc-test-rule-group"
+    type = "prometheus"
+    name = "terraform-acc-test-rule-group"
+    url  = "http://terraform-acc-test.invalid/"
+}
+
+resource "grafana_rule_group" "test" {
+    name             = "Terraform Acceptance Test"
+    folder_uid       = "${grafana_folder.test.uid}"
+    interval_seconds = 60
+
+    rule {
+        name      = "Terraform Acceptance Test Rule"
+        condition = "B"
+        for       = "%s"
+
+        labels {
+            severity = "warning"
+        }
+
+        data {
+            ref_id         = "A"
+            datasource_uid = "${grafana_data_source.test.uid}"
+            model          = "{\"refId\": \"A\", \"expr\": \"up\"}"
+
+            relative_time_range {
+                from = 600
+                to   = 0
+            }
+        }
+
+        data {
+            ref_id         = "B"
+            datasource_uid = "__expr__"
+            model          = "{\"refId\": \"B\", \"type\": \"math\", \"expression\": \"$A < 1\"}"
+
+            relative_time_range {
+                from = 0
+                to   = 0
+            }
+        }
+    }
+}
+`, duration)
+}
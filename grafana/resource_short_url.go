@@ -0,0 +1,81 @@
+package grafana
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func ResourceShortURL() *schema.Resource {
+	return &schema.Resource{
+		Create: CreateShortURL,
+		Read:   ReadShortURL,
+		Delete: DeleteShortURL,
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDSchema(),
+
+			"path": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateShortURLPath,
+			},
+
+			"uid": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"url": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func CreateShortURL(d *schema.ResourceData, meta interface{}) error {
+	if err := meta.(*client).requireVersion("grafana_short_url", "7.3.0"); err != nil {
+		return err
+	}
+
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	shortURL, err := client.NewShortURL(d.Get("path").(string))
+	if err != nil {
+		return accessError(err, "creating short URL")
+	}
+
+	d.SetId(shortURL.Uid)
+	d.Set("uid", shortURL.Uid)
+	d.Set("url", shortURL.Url)
+
+	return nil
+}
+
+// ReadShortURL does nothing: short URLs can't be changed, and Grafana has
+// no API to read them.
+func ReadShortURL(d *schema.ResourceData, meta interface{}) error {
+	return nil
+}
+
+// DeleteShortURL only removes the short URL from state, as Grafana has no
+// API to delete short URLs. Grafana deletes the ones that go unused itself.
+func DeleteShortURL(d *schema.ResourceData, meta interface{}) error {
+	return nil
+}
+
+// validateShortURLPath checks that the path is relative to the Grafana
+// server's URL, as Grafana requires.
+func validateShortURLPath(v interface{}, k string) ([]string, []error) {
+	path := v.(string)
+	if strings.HasPrefix(path, "/") || strings.Contains(path, "://") || strings.Contains(path, "..") {
+		return nil, []error{fmt.Errorf("%q must be a path relative to the Grafana server's URL, such as d/cIBgcSjkk/metrics, got %q", k, path)}
+	}
+	return nil, nil
+}
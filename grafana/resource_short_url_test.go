@@ -0,0 +1,55 @@
+package grafana
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccShortURL_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccShortURLConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"grafana_short_url.test", "id", "grafana_short_url.test", "uid",
+					),
+					resource.TestCheckResourceAttrSet("grafana_short_url.test", "url"),
+				),
+			},
+		},
+	})
+}
+
+func TestValidateShortURLPath(t *testing.T) {
+	cases := map[string]bool{
+		"d/cIBgcSjkk/metrics?from=now-1h&var-env=prod": true,
+		"explore":                       true,
+		"/d/cIBgcSjkk/metrics":          false,
+		"https://grafana.local/d/abc":   false,
+		"d/cIBgcSjkk/../../admin/users": false,
+	}
+	for path, valid := range cases {
+		_, errs := validateShortURLPath(path, "path")
+		if (len(errs) == 0) != valid {
+			t.Errorf("validateShortURLPath(%q) returned %v, expected valid to be %t", path, errs, valid)
+		}
+	}
+}
+
+const testAccShortURLConfig_basic = `
+resource "grafana_dashboard" "test" {
+    config_json = <<EOT
+{
+    "title": "Terraform Acceptance Test Short URL"
+}
+EOT
+}
+
+resource "grafana_short_url" "test" {
+    path = "d/${grafana_dashboard.test.uid}/${grafana_dashboard.test.slug}?from=now-1h&to=now"
+}
+`
@@ -0,0 +1,290 @@
+package grafana
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	gapi "github.com/nytm/go-grafana-api"
+)
+
+func ResourceSilence() *schema.Resource {
+	return &schema.Resource{
+		Create: CreateSilence,
+		Read:   ReadSilence,
+		Update: UpdateSilence,
+		Delete: DeleteSilence,
+		Importer: &schema.ResourceImporter{
+			State: importOrgResource,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDSchema(),
+
+			"matcher": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"label": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"match": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateStringIn("=", "!=", "=~", "!~"),
+						},
+
+						"value": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+
+			"starts_at": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateFunc:     validateAnnotationTime,
+				DiffSuppressFunc: suppressSilenceStartDiff,
+			},
+
+			"ends_at": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ConflictsWith:    []string{"duration"},
+				ValidateFunc:     validateAnnotationTime,
+				DiffSuppressFunc: suppressEqualAnnotationTimes,
+			},
+
+			"duration": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ends_at"},
+				ValidateFunc:  validateAlertingDuration,
+			},
+
+			"comment": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"created_by": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Terraform",
+			},
+
+			"state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func CreateSilence(d *schema.ResourceData, meta interface{}) error {
+	if err := meta.(*client).requireVersion("grafana_silence", "8.0.0"); err != nil {
+		return err
+	}
+
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	silence, err := makeSilence(d)
+	if err != nil {
+		return err
+	}
+	id, err := client.SaveSilence(silence)
+	if err != nil {
+		return accessError(err, "creating silence")
+	}
+
+	setOrgResourceID(d, id)
+
+	return ReadSilence(d, meta)
+}
+
+// UpdateSilence updates the silence, which the Alertmanager does by
+// replacing it with a new one when its matchers or the start of an active
+// silence change.
+func UpdateSilence(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	silence, err := makeSilence(d)
+	if err != nil {
+		return err
+	}
+	silence.Id = orgResourceID(d)
+	id, err := client.SaveSilence(silence)
+	if err != nil {
+		return accessError(err, fmt.Sprintf("updating silence %s", orgResourceID(d)))
+	}
+
+	setOrgResourceID(d, id)
+
+	return ReadSilence(d, meta)
+}
+
+func ReadSilence(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	silence, err := client.Silence(orgResourceID(d))
+	if err != nil {
+		if !isNotFound(err) {
+			return accessError(err, fmt.Sprintf("reading silence %s", orgResourceID(d)))
+		}
+		// The Alertmanager deletes silences some time after they end. They
+		// are kept in state so that they aren't created again.
+		if endsAt, err := time.Parse(time.RFC3339, d.Get("ends_at").(string)); err == nil && endsAt.Before(time.Now()) {
+			d.Set("state", "expired")
+			return nil
+		}
+		log.Printf("[WARN] removing silence %s from state because it no longer exists in grafana", orgResourceID(d))
+		d.SetId("")
+		return nil
+	}
+
+	var matchers []interface{}
+	for _, matcher := range silence.Matchers {
+		matchers = append(matchers, map[string]interface{}{
+			"label": matcher.Name,
+			"match": silenceMatchOperator(matcher),
+			"value": matcher.Value,
+		})
+	}
+
+	if err := d.Set("matcher", matchers); err != nil {
+		return err
+	}
+	d.Set("starts_at", silence.StartsAt.UTC().Format(time.RFC3339Nano))
+	d.Set("ends_at", silence.EndsAt.UTC().Format(time.RFC3339Nano))
+	d.Set("comment", silence.Comment)
+	d.Set("created_by", silence.CreatedBy)
+	if silence.Status != nil {
+		d.Set("state", silence.Status.State)
+	}
+
+	return nil
+}
+
+// DeleteSilence expires the silence, unless it already has.
+func DeleteSilence(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	silence, err := client.Silence(orgResourceID(d))
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return accessError(err, fmt.Sprintf("reading silence %s", orgResourceID(d)))
+	}
+	if silence.Status != nil && silence.Status.State == "expired" {
+		return nil
+	}
+
+	if err := client.ExpireSilence(orgResourceID(d)); err != nil && !isNotFound(err) {
+		return accessError(err, fmt.Sprintf("expiring silence %s", orgResourceID(d)))
+	}
+
+	return nil
+}
+
+func makeSilence(d *schema.ResourceData) (*gapi.Silence, error) {
+	silence := &gapi.Silence{
+		StartsAt:  time.Now().UTC(),
+		CreatedBy: d.Get("created_by").(string),
+		Comment:   d.Get("comment").(string),
+	}
+
+	for _, matcher := range d.Get("matcher").([]interface{}) {
+		matcher := matcher.(map[string]interface{})
+		match := matcher["match"].(string)
+		silence.Matchers = append(silence.Matchers, gapi.SilenceMatcher{
+			Name:    matcher["label"].(string),
+			Value:   matcher["value"].(string),
+			IsEqual: match == "=" || match == "=~",
+			IsRegex: match == "=~" || match == "!~",
+		})
+	}
+
+	if startsAt := d.Get("starts_at").(string); startsAt != "" {
+		t, err := time.Parse(time.RFC3339, startsAt)
+		if err != nil {
+			return nil, err
+		}
+		silence.StartsAt = t
+	}
+
+	duration, endsAt := d.Get("duration").(string), d.Get("ends_at").(string)
+	switch {
+	case duration != "":
+		length, err := parseAlertingDuration(duration)
+		if err != nil {
+			return nil, err
+		}
+		silence.EndsAt = silence.StartsAt.Add(length)
+	case endsAt != "":
+		t, err := time.Parse(time.RFC3339, endsAt)
+		if err != nil {
+			return nil, err
+		}
+		silence.EndsAt = t
+	default:
+		return nil, fmt.Errorf("One of ends_at or duration must be set")
+	}
+	if !silence.EndsAt.After(silence.StartsAt) {
+		return nil, fmt.Errorf("The silence must end after it starts, at %s", silence.StartsAt.Format(time.RFC3339))
+	}
+
+	return silence, nil
+}
+
+func silenceMatchOperator(matcher gapi.SilenceMatcher) string {
+	switch {
+	case matcher.IsEqual && matcher.IsRegex:
+		return "=~"
+	case matcher.IsRegex:
+		return "!~"
+	case matcher.IsEqual:
+		return "="
+	default:
+		return "!="
+	}
+}
+
+// suppressSilenceStartDiff ignores differences in how the same time is
+// written, as well as starts in the past, which the Alertmanager moves to
+// when the silence is created.
+func suppressSilenceStartDiff(k, old, new string, d *schema.ResourceData) bool {
+	if suppressEqualAnnotationTimes(k, old, new, d) {
+		return true
+	}
+	oldTime, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+	newTime, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+	return newTime.Before(oldTime) && newTime.Before(time.Now())
+}
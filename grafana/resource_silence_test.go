@@ -0,0 +1,228 @@
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	gapi "github.com/nytm/go-grafana-api"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccSilence_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccSilenceCheckDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccSilenceConfig("Planned maintenance"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccSilenceCheckState("grafana_silence.test", "active"),
+					resource.TestCheckResourceAttr("grafana_silence.test", "matcher.#", "2"),
+					resource.TestCheckResourceAttr("grafana_silence.test", "matcher.1.match", "=~"),
+					resource.TestCheckResourceAttrSet("grafana_silence.test", "ends_at"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccSilenceConfig("Extended maintenance"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccSilenceCheckState("grafana_silence.test", "active"),
+					resource.TestCheckResourceAttr("grafana_silence.test", "comment", "Extended maintenance"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:            "grafana_silence.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"duration"},
+			},
+		},
+	})
+}
+
+func TestSilence_lifecycle(t *testing.T) {
+	silences := map[string]*gapi.Silence{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/alertmanager/grafana/api/v2/silences":
+			silence := &gapi.Silence{}
+			if err := json.NewDecoder(r.Body).Decode(silence); err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			// Changing the matchers replaces the silence.
+			if old, ok := silences[silence.Id]; !ok || !reflect.DeepEqual(old.Matchers, silence.Matchers) {
+				if ok {
+					old.Status.State = "expired"
+				}
+				silence.Id = fmt.Sprintf("silence-%d", len(silences)+1)
+			}
+			silence.Status = &gapi.SilenceStatus{State: "active"}
+			silences[silence.Id] = silence
+			json.NewEncoder(w).Encode(map[string]string{"silenceID": silence.Id})
+		case strings.HasPrefix(r.URL.Path, "/api/alertmanager/grafana/api/v2/silence/"):
+			silence, ok := silences[strings.TrimPrefix(r.URL.Path, "/api/alertmanager/grafana/api/v2/silence/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			if r.Method == "DELETE" {
+				silence.Status.State = "expired"
+			}
+			json.NewEncoder(w).Encode(silence)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	d := schema.TestResourceDataRaw(t, ResourceSilence().Schema, map[string]interface{}{
+		"comment":  "Planned maintenance",
+		"duration": "2h",
+		"matcher": []interface{}{
+			map[string]interface{}{"label": "cluster", "match": "=", "value": "eu-1"},
+			map[string]interface{}{"label": "alertname", "match": "!~", "value": "Watchdog|Heartbeat"},
+		},
+	})
+	if err := CreateSilence(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	silence := silences["silence-1"]
+	if d.Id() != "silence-1" || silence == nil {
+		t.Fatalf("expected silence-1 to be created, got %q", d.Id())
+	}
+	if length := silence.EndsAt.Sub(silence.StartsAt); length != 2*time.Hour {
+		t.Fatalf("expected the silence to last 2h, got %s", length)
+	}
+	if m := silence.Matchers[1]; m.IsEqual || !m.IsRegex || silence.CreatedBy != "Terraform" {
+		t.Fatalf("unexpected silence %#v", silence)
+	}
+	if match := d.Get("matcher.1.match").(string); match != "!~" {
+		t.Fatalf("expected the match operator to be read back, got %q", match)
+	}
+
+	d.Set("matcher", []interface{}{
+		map[string]interface{}{"label": "cluster", "match": "=", "value": "eu-2"},
+	})
+	if err := UpdateSilence(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Id() != "silence-2" || silences["silence-1"].Status.State != "expired" {
+		t.Fatalf("expected silence-1 to be replaced by silence-2, got %q", d.Id())
+	}
+	if !silences["silence-2"].StartsAt.Equal(silence.StartsAt) {
+		t.Fatalf("expected the start of the silence to be kept, got %s", silences["silence-2"].StartsAt)
+	}
+
+	if err := DeleteSilence(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if silences["silence-2"].Status.State != "expired" {
+		t.Fatalf("expected the silence to be expired")
+	}
+
+	// Silences deleted by the Alertmanager after they ended stay in state.
+	delete(silences, "silence-2")
+	d.Set("ends_at", time.Now().Add(-time.Hour).Format(time.RFC3339))
+	if err := ReadSilence(d, c); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Id() != "silence-2" || d.Get("state").(string) != "expired" {
+		t.Fatalf("expected the ended silence to stay in state as expired, got %q", d.Id())
+	}
+}
+
+func TestMakeSilence_endsAt(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, ResourceSilence().Schema, map[string]interface{}{
+		"comment":   "Planned maintenance",
+		"starts_at": "2030-01-01T02:00:00+01:00",
+		"ends_at":   "2030-01-01T00:00:00Z",
+		"matcher": []interface{}{
+			map[string]interface{}{"label": "cluster", "match": "=", "value": "eu-1"},
+		},
+	})
+	if _, err := makeSilence(d); err == nil {
+		t.Fatalf("expected an error for a silence that ends before it starts")
+	}
+
+	d.Set("ends_at", "2030-01-01T03:00:00Z")
+	silence, err := makeSilence(d)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if length := silence.EndsAt.Sub(silence.StartsAt); length != 2*time.Hour {
+		t.Fatalf("expected the silence to last 2h, got %s", length)
+	}
+
+	d.Set("ends_at", "")
+	if _, err := makeSilence(d); err == nil || !strings.Contains(err.Error(), "ends_at or duration") {
+		t.Fatalf("expected an error for a silence without an end, got %v", err)
+	}
+}
+
+func testAccSilenceCheckState(rn, state string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		client := testAccProvider.Meta().(*client).gapi
+		silence, err := client.Silence(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error getting silence: %s", err)
+		}
+		if silence.Status == nil || silence.Status.State != state {
+			return fmt.Errorf("expected silence %s to be %s, got %#v", rs.Primary.ID, state, silence.Status)
+		}
+
+		return nil
+	}
+}
+
+func testAccSilenceCheckDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*client).gapi
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "grafana_silence" {
+			continue
+		}
+		silence, err := client.Silence(rs.Primary.ID)
+		if err == nil && silence.Status != nil && silence.Status.State != "expired" {
+			return fmt.Errorf("silence %s is still %s", rs.Primary.ID, silence.Status.State)
+		}
+	}
+	return nil
+}
+
+func testAccSilenceConfig(comment string) string {
+	return fmt.Sprintf(`
+resource "grafana_silence" "test" {
+    comment  = "%s"
+    duration = "2h"
+
+    matcher {
+        label = "cluster"
+        match = "="
+        value = "terraform-acc-test"
+    }
+
+    matcher {
+        label = "alertname"
+        match = "=~"
+        value = "High.*"
+    }
+}
+`, comment)
+}
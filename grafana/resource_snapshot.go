@@ -0,0 +1,116 @@
+package grafana
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	gapi "github.com/nytm/go-grafana-api"
+)
+
+func ResourceSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Create: CreateSnapshot,
+		Read:   ReadSnapshot,
+		Delete: DeleteSnapshot,
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDSchema(),
+
+			"config_json": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				StateFunc:    NormalizeDashboardConfigJSON,
+				ValidateFunc: ValidateDashboardConfigJSON,
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"expires": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateNonNegative,
+			},
+
+			"key": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"delete_key": &schema.Schema{
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"url": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func CreateSnapshot(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.NewSnapshot(gapi.NewSnapshot{
+		Dashboard: prepareDashboardModel(d.Get("config_json").(string)),
+		Name:      d.Get("name").(string),
+		Expires:   int64(d.Get("expires").(int)),
+	})
+	if err != nil {
+		return accessError(err, "creating snapshot")
+	}
+
+	d.SetId(resp.Key)
+	d.Set("key", resp.Key)
+	d.Set("delete_key", resp.DeleteKey)
+	d.Set("url", resp.Url)
+
+	return ReadSnapshot(d, meta)
+}
+
+func ReadSnapshot(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	// Snapshots can't be changed, so only whether the snapshot still exists
+	// is read. Grafana deletes snapshots once they expire.
+	_, err = client.Snapshot(d.Id())
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] removing snapshot %s from state because it no longer exists in grafana", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return accessError(err, fmt.Sprintf("reading snapshot %s", d.Id()))
+	}
+
+	return nil
+}
+
+func DeleteSnapshot(d *schema.ResourceData, meta interface{}) error {
+	client, err := orgClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	err = client.DeleteSnapshot(d.Get("delete_key").(string))
+	if err != nil && !isNotFound(err) {
+		return accessError(err, fmt.Sprintf("deleting snapshot %s", d.Id()))
+	}
+
+	return nil
+}
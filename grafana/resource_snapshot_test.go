@@ -0,0 +1,93 @@
+package grafana
+
+import (
+	"fmt"
+	"testing"
+
+	gapi "github.com/nytm/go-grafana-api"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccSnapshot_basic(t *testing.T) {
+	var snapshot gapi.Snapshot
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccSnapshotCheckDestroy("grafana_snapshot.test"),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccSnapshotConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccSnapshotCheckExists("grafana_snapshot.test", &snapshot),
+					resource.TestCheckResourceAttrPair(
+						"grafana_snapshot.test", "id", "grafana_snapshot.test", "key",
+					),
+					resource.TestCheckResourceAttrSet("grafana_snapshot.test", "delete_key"),
+					resource.TestCheckResourceAttrSet("grafana_snapshot.test", "url"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSnapshotCheckExists(rn string, snapshot *gapi.Snapshot) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("resource id not set")
+		}
+
+		client := testAccProvider.Meta().(*client).gapi
+		gotSnapshot, err := client.Snapshot(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error getting snapshot: %s", err)
+		}
+
+		if gotSnapshot.Model["title"] != "Terraform Acceptance Test Snapshot" {
+			return fmt.Errorf("unexpected snapshot dashboard %v", gotSnapshot.Model)
+		}
+
+		*snapshot = *gotSnapshot
+
+		return nil
+	}
+}
+
+// testAccSnapshotCheckDestroy looks the snapshot up by the key in the state,
+// since the snapshots Grafana returns don't include it.
+func testAccSnapshotCheckDestroy(rn string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return nil
+		}
+
+		client := testAccProvider.Meta().(*client).gapi
+		_, err := client.Snapshot(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("snapshot still exists")
+		}
+		return nil
+	}
+}
+
+const testAccSnapshotConfig_basic = `
+resource "grafana_snapshot" "test" {
+    name    = "Terraform Acceptance Test Snapshot"
+    expires = 3600
+
+    config_json = <<EOT
+{
+    "title": "Terraform Acceptance Test Snapshot",
+    "panels": [{"id": 1, "type": "text", "title": "Incident"}]
+}
+EOT
+}
+`
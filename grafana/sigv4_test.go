@@ -0,0 +1,51 @@
+package grafana
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+)
+
+func TestSigV4Transport(t *testing.T) {
+	var gotAuth, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		body, _ := ioutil.ReadAll(r.Body)
+		gotBody = string(body)
+	}))
+	defer server.Close()
+
+	transport := &sigV4Transport{
+		signer:    v4.NewSigner(credentials.NewStaticCredentials("AKIDEXAMPLE", "secret", "")),
+		region:    "us-east-1",
+		transport: http.DefaultTransport,
+	}
+
+	req, err := http.NewRequest("POST", server.URL+"/api/dashboards/db", bytes.NewBufferString(`{"title": "test"}`))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	req.Header.Set("Authorization", "Bearer sigv4")
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	resp.Body.Close()
+
+	if !strings.HasPrefix(gotAuth, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") {
+		t.Errorf("expected a SigV4 Authorization header, got %q", gotAuth)
+	}
+	if !strings.Contains(gotAuth, "/us-east-1/grafana/aws4_request") {
+		t.Errorf("expected the request to be signed for grafana in us-east-1, got %q", gotAuth)
+	}
+	if gotBody != `{"title": "test"}` {
+		t.Errorf("expected the request body to be sent, got %q", gotBody)
+	}
+}
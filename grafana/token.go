@@ -0,0 +1,110 @@
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// tokenExpiryDelta is how long before its expiry a token is refreshed, so
+// that it doesn't expire while a request is in flight.
+const tokenExpiryDelta = 1 * time.Minute
+
+// token is an access token along with the time it expires at.
+type token struct {
+	accessToken string
+	expiry      time.Time
+}
+
+func (t *token) valid() bool {
+	return t != nil && t.accessToken != "" && (t.expiry.IsZero() || time.Now().Add(tokenExpiryDelta).Before(t.expiry))
+}
+
+// tokenTransport authenticates every request made through it with a bearer
+// token obtained from fetch, fetching a new one whenever the current token
+// is about to expire.
+type tokenTransport struct {
+	fetch     func() (*token, error)
+	transport http.RoundTripper
+
+	mu      sync.Mutex
+	current *token
+}
+
+func (t *tokenTransport) token() (*token, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.current.valid() {
+		tok, err := t.fetch()
+		if err != nil {
+			return nil, fmt.Errorf("Error obtaining access token: %s", err)
+		}
+		t.current = tok
+	}
+
+	return t.current, nil
+}
+
+func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	tok, err := t.token()
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+tok.accessToken)
+	return t.transport.RoundTrip(req)
+}
+
+// doTokenRequest sends a request to an OAuth2 style token endpoint and
+// parses the token it responds with.
+func doTokenRequest(httpClient *http.Client, req *http.Request) (*token, error) {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	result := struct {
+		AccessToken      string      `json:"access_token"`
+		ExpiresIn        json.Number `json:"expires_in"`
+		Error            string      `json:"error"`
+		ErrorDescription string      `json:"error_description"`
+	}{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("%s: unexpected response from %s", resp.Status, req.URL.Host)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		if result.Error != "" {
+			return nil, fmt.Errorf("%s: %s %s", resp.Status, result.Error, result.ErrorDescription)
+		}
+		return nil, fmt.Errorf("%s", resp.Status)
+	}
+	if result.AccessToken == "" {
+		return nil, fmt.Errorf("no access token in the response from %s", req.URL.Host)
+	}
+
+	tok := &token{accessToken: result.AccessToken}
+	if result.ExpiresIn != "" {
+		// Some endpoints, e.g. Azure's instance metadata service, send the
+		// number of seconds as a string.
+		seconds, err := strconv.ParseInt(result.ExpiresIn.String(), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expires_in %q in the response from %s", result.ExpiresIn, req.URL.Host)
+		}
+		tok.expiry = time.Now().Add(time.Duration(seconds) * time.Second)
+	}
+
+	return tok, nil
+}
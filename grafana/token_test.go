@@ -0,0 +1,97 @@
+package grafana
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTokenTransport_refresh(t *testing.T) {
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+
+	fetches := 0
+	expiresIn := time.Hour
+	transport := &tokenTransport{
+		fetch: func() (*token, error) {
+			fetches++
+			return &token{
+				accessToken: fmt.Sprintf("token-%d", fetches),
+				expiry:      time.Now().Add(expiresIn),
+			}, nil
+		},
+		transport: http.DefaultTransport,
+	}
+	client := &http.Client{Transport: transport}
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		resp.Body.Close()
+	}
+	if fetches != 1 || gotAuth != "Bearer token-1" {
+		t.Fatalf("expected a single token to be reused, got %d fetches and %q", fetches, gotAuth)
+	}
+
+	// A token about to expire is replaced before it's used.
+	transport.current.expiry = time.Now().Add(tokenExpiryDelta / 2)
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	resp.Body.Close()
+	if fetches != 2 || gotAuth != "Bearer token-2" {
+		t.Fatalf("expected the token to be refreshed, got %d fetches and %q", fetches, gotAuth)
+	}
+}
+
+func TestDoTokenRequest(t *testing.T) {
+	cases := []struct {
+		status    int
+		body      string
+		token     string
+		hasExpiry bool
+	}{
+		{http.StatusOK, `{"access_token": "abc", "token_type": "Bearer", "expires_in": 3599}`, "abc", true},
+		{http.StatusOK, `{"access_token": "abc", "token_type": "Bearer", "expires_in": "3599"}`, "abc", true},
+		{http.StatusOK, `{"access_token": "abc"}`, "abc", false},
+		{http.StatusOK, `{"token_type": "Bearer"}`, "", false},
+		{http.StatusUnauthorized, `{"error": "invalid_client", "error_description": "bad secret"}`, "", false},
+		{http.StatusOK, `not json`, "", false},
+	}
+
+	for _, tc := range cases {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tc.status)
+			w.Write([]byte(tc.body))
+		}))
+
+		req, _ := http.NewRequest("POST", server.URL, nil)
+		tok, err := doTokenRequest(http.DefaultClient, req)
+		server.Close()
+
+		if tc.token == "" {
+			if err == nil {
+				t.Errorf("expected %q to fail", tc.body)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("expected %q to succeed, got %s", tc.body, err)
+			continue
+		}
+		if tok.accessToken != tc.token {
+			t.Errorf("expected token %q, got %q", tc.token, tok.accessToken)
+		}
+		if tok.expiry.IsZero() == tc.hasExpiry {
+			t.Errorf("unexpected expiry %s for %q", tok.expiry, tc.body)
+		}
+	}
+}
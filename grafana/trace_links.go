@@ -0,0 +1,185 @@
+package grafana
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	gapi "github.com/nytm/go-grafana-api"
+)
+
+// derivedFieldSchema is the schema of the fields Loki data sources derive
+// from log lines, e.g. to link them to the traces they mention.
+func derivedFieldSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		ConflictsWith: []string{"json_data_encoded"},
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": &schema.Schema{
+					Type:     schema.TypeString,
+					Required: true,
+				},
+
+				"matcher_regex": &schema.Schema{
+					Type:     schema.TypeString,
+					Required: true,
+				},
+
+				"matcher_type": &schema.Schema{
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validateStringIn("regex", "label"),
+				},
+
+				"url": &schema.Schema{
+					Type:     schema.TypeString,
+					Required: true,
+				},
+
+				"data_source_uid": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+
+				"url_display_label": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+			},
+		},
+	}
+}
+
+// exemplarDestinationSchema is the schema of where Prometheus data sources
+// link the trace IDs of exemplars to.
+func exemplarDestinationSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		ConflictsWith: []string{"json_data_encoded"},
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": &schema.Schema{
+					Type:     schema.TypeString,
+					Required: true,
+				},
+
+				"data_source_uid": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+
+				"url": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+
+				"url_display_label": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+			},
+		},
+	}
+}
+
+// traceLinks are the blocks that link data sources to traces, along with
+// the key Grafana stores them under in JSON data, the data source type they
+// apply to, and the keys of their attributes.
+var traceLinks = []struct {
+	attribute      string
+	key            string
+	dataSourceType string
+	keys           map[string]string
+}{
+	{
+		attribute:      "derived_field",
+		key:            "derivedFields",
+		dataSourceType: "loki",
+		keys: map[string]string{
+			"name":              "name",
+			"matcher_regex":     "matcherRegex",
+			"matcher_type":      "matcherType",
+			"url":               "url",
+			"data_source_uid":   "datasourceUid",
+			"url_display_label": "urlDisplayLabel",
+		},
+	},
+	{
+		attribute:      "exemplar_destination",
+		key:            "exemplarTraceIdDestinations",
+		dataSourceType: "prometheus",
+		keys: map[string]string{
+			"name":              "name",
+			"data_source_uid":   "datasourceUid",
+			"url":               "url",
+			"url_display_label": "urlDisplayLabel",
+		},
+	},
+}
+
+// validateTraceLinks checks that derived fields and exemplar destinations
+// are only given to data sources of the matching type, and that exemplars
+// link somewhere.
+func validateTraceLinks(d *schema.ResourceData) []error {
+	dataSourceType := d.Get("type").(string)
+
+	var errors []error
+	for _, link := range traceLinks {
+		if _, ok := d.GetOk(link.attribute); ok && dataSourceType != link.dataSourceType {
+			errors = append(errors, fmt.Errorf("%s only applies to data sources of type %s", link.attribute, link.dataSourceType))
+		}
+	}
+	for i, destination := range d.Get("exemplar_destination").([]interface{}) {
+		destination := destination.(map[string]interface{})
+		if destination["data_source_uid"].(string) == "" && destination["url"].(string) == "" {
+			errors = append(errors, fmt.Errorf("exemplar_destination.%d must set data_source_uid or url", i))
+		}
+	}
+	return errors
+}
+
+// makeTraceLinks adds the derived fields and exemplar destinations to the
+// JSON data of a data source, leaving out the attributes that aren't set.
+func makeTraceLinks(d *schema.ResourceData, jsonData map[string]interface{}) {
+	for _, link := range traceLinks {
+		blocks, ok := d.GetOk(link.attribute)
+		if !ok {
+			continue
+		}
+		var values []interface{}
+		for _, block := range blocks.([]interface{}) {
+			block := block.(map[string]interface{})
+			value := map[string]interface{}{}
+			for name, key := range link.keys {
+				if v := block[name].(string); v != "" {
+					value[key] = v
+				}
+			}
+			values = append(values, value)
+		}
+		jsonData[link.key] = values
+	}
+}
+
+// readTraceLinks reads the derived fields and exemplar destinations back
+// from the JSON data of a data source.
+func readTraceLinks(d *schema.ResourceData, dataSource *gapi.DataSource) {
+	for _, link := range traceLinks {
+		values, _ := dataSource.JSONData[link.key].([]interface{})
+		var blocks []interface{}
+		for _, value := range values {
+			value, ok := value.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			block := map[string]interface{}{}
+			for name, key := range link.keys {
+				block[name], _ = value[key].(string)
+			}
+			blocks = append(blocks, block)
+		}
+		d.Set(link.attribute, blocks)
+	}
+}
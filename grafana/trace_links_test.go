@@ -0,0 +1,86 @@
+package grafana
+
+import (
+	"reflect"
+	"testing"
+
+	gapi "github.com/nytm/go-grafana-api"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestMakeTraceLinks(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, ResourceDataSource().Schema, map[string]interface{}{
+		"type": "loki",
+		"derived_field": []interface{}{
+			map[string]interface{}{
+				"name":            "TraceID",
+				"matcher_regex":   `traceID=(\w+)`,
+				"url":             "$${__value.raw}",
+				"data_source_uid": "tempo",
+			},
+		},
+	})
+	dataSource, err := makeDataSource(d)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	expected := map[string]interface{}{
+		"derivedFields": []interface{}{
+			map[string]interface{}{
+				"name":          "TraceID",
+				"matcherRegex":  `traceID=(\w+)`,
+				"url":           "$${__value.raw}",
+				"datasourceUid": "tempo",
+			},
+		},
+	}
+	if !reflect.DeepEqual(dataSource.JSONData, expected) {
+		t.Fatalf("expected JSON data %v, got %v", expected, dataSource.JSONData)
+	}
+}
+
+func TestValidateTraceLinks(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, ResourceDataSource().Schema, map[string]interface{}{
+		"type": "loki",
+		"exemplar_destination": []interface{}{
+			map[string]interface{}{"name": "trace_id"},
+		},
+	})
+	var messages []string
+	for _, err := range validateTraceLinks(d) {
+		messages = append(messages, err.Error())
+	}
+	expected := []string{
+		"exemplar_destination only applies to data sources of type prometheus",
+		"exemplar_destination.0 must set data_source_uid or url",
+	}
+	if !reflect.DeepEqual(messages, expected) {
+		t.Fatalf("expected errors %q, got %q", expected, messages)
+	}
+}
+
+func TestReadTraceLinks(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, ResourceDataSource().Schema, map[string]interface{}{
+		"type": "prometheus",
+	})
+	readTraceLinks(d, &gapi.DataSource{
+		JSONData: map[string]interface{}{
+			"httpMethod": "POST",
+			"exemplarTraceIdDestinations": []interface{}{
+				map[string]interface{}{"name": "trace_id", "datasourceUid": "tempo"},
+				map[string]interface{}{"name": "span_id", "url": "https://traces.example.net/${__value.raw}", "urlDisplayLabel": "Show"},
+			},
+		},
+	})
+
+	if d.Get("exemplar_destination.#").(int) != 2 {
+		t.Fatalf("expected 2 exemplar destinations, got %v", d.Get("exemplar_destination"))
+	}
+	if d.Get("exemplar_destination.0.data_source_uid").(string) != "tempo" || d.Get("exemplar_destination.1.url_display_label").(string) != "Show" {
+		t.Fatalf("unexpected exemplar destinations %v", d.Get("exemplar_destination"))
+	}
+	if d.Get("derived_field.#").(int) != 0 {
+		t.Fatalf("expected no derived fields, got %v", d.Get("derived_field"))
+	}
+}
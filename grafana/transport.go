@@ -0,0 +1,251 @@
+package grafana
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httputil"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform/helper/logging"
+)
+
+// contextTransport binds every request made through it to ctx. The provider
+// uses its stop context here, so requests still in flight are aborted as soon
+// as Terraform asks the provider to stop, e.g. when the user hits Ctrl-C.
+type contextTransport struct {
+	ctx       context.Context
+	transport http.RoundTripper
+}
+
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.transport.RoundTrip(req.WithContext(t.ctx))
+}
+
+// rateLimitTransport bounds the rate at which requests are sent and, when
+// sem is set, the number of requests in flight at any time. A single
+// instance is shared by every client the provider creates, so all resources
+// draw from the same budget however high Terraform's parallelism is.
+type rateLimitTransport struct {
+	interval  time.Duration
+	sem       chan struct{}
+	transport http.RoundTripper
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+func newRateLimitTransport(rateLimit, maxParallel int, transport http.RoundTripper) *rateLimitTransport {
+	t := &rateLimitTransport{transport: transport}
+	if rateLimit > 0 {
+		t.interval = time.Second / time.Duration(rateLimit)
+	}
+	if maxParallel > 0 {
+		t.sem = make(chan struct{}, maxParallel)
+	}
+	return t
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if t.sem != nil {
+		select {
+		case t.sem <- struct{}{}:
+			defer func() { <-t.sem }()
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if err := t.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	return t.transport.RoundTrip(req)
+}
+
+// wait blocks until the request may be sent. Each caller reserves the next
+// free slot before sleeping, so concurrent callers are spread out evenly.
+func (t *rateLimitTransport) wait(ctx context.Context) error {
+	if t.interval == 0 {
+		return nil
+	}
+
+	t.mu.Lock()
+	now := time.Now()
+	at := t.next
+	if at.Before(now) {
+		at = now
+	}
+	t.next = at.Add(t.interval)
+	t.mu.Unlock()
+
+	delay := at.Sub(now)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// userAgentTransport identifies the provider in the User-Agent header of
+// every request made through it and, when requestID is set, tags each
+// request with a unique X-Request-Id so that Terraform-driven changes can be
+// traced in the Grafana server's logs.
+type userAgentTransport struct {
+	userAgent string
+	requestID bool
+	transport http.RoundTripper
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+
+	if t.requestID {
+		id, err := uuid.GenerateUUID()
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("X-Request-Id", id)
+	}
+
+	return t.transport.RoundTrip(req)
+}
+
+// orgIDTransport scopes every request made through it to a single Grafana
+// organization by setting the X-Grafana-Org-Id header.
+type orgIDTransport struct {
+	orgID     int64
+	transport http.RoundTripper
+}
+
+func (t *orgIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("X-Grafana-Org-Id", strconv.FormatInt(t.orgID, 10))
+	return t.transport.RoundTrip(req)
+}
+
+// headerTransport adds a fixed set of headers to every request made through
+// it, e.g. for identity-aware proxies sitting in front of Grafana.
+type headerTransport struct {
+	headers   map[string]string
+	transport http.RoundTripper
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for k, v := range t.headers {
+		req.Header.Set(k, v)
+	}
+	return t.transport.RoundTrip(req)
+}
+
+// redactedHeaders are never written to the debug log by loggingTransport.
+var redactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// loggingTransport dumps every request and response to the log when TF_LOG
+// is set to DEBUG or higher. Unlike logging.NewTransport, credentials are
+// redacted: the headers in redactedHeaders, any extra headers given in
+// redact, and basic auth credentials embedded in the URL.
+type loggingTransport struct {
+	redact    []string
+	transport http.RoundTripper
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if logging.IsDebugOrHigher() {
+		if err := t.logRequest(req); err != nil {
+			log.Printf("[ERROR] Grafana API Request error: %#v", err)
+		}
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if logging.IsDebugOrHigher() {
+		if err := t.logResponse(resp); err != nil {
+			log.Printf("[ERROR] Grafana API Response error: %#v", err)
+		}
+	}
+
+	return resp, nil
+}
+
+func (t *loggingTransport) logRequest(req *http.Request) error {
+	dumpReq := req.Clone(req.Context())
+	if req.Body != nil {
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return err
+		}
+		req.Body.Close()
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		dumpReq.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	if dumpReq.URL.User != nil {
+		u := *dumpReq.URL
+		u.User = nil
+		dumpReq.URL = &u
+		dumpReq.Header.Set("Authorization", "[REDACTED]")
+	}
+	t.redactHeaders(dumpReq.Header)
+
+	data, err := httputil.DumpRequestOut(dumpReq, true)
+	if err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] "+logReqMsg, string(data))
+	return nil
+}
+
+func (t *loggingTransport) logResponse(resp *http.Response) error {
+	header := resp.Header
+	resp.Header = header.Clone()
+	t.redactHeaders(resp.Header)
+
+	data, err := httputil.DumpResponse(resp, true)
+	resp.Header = header
+	if err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] "+logRespMsg, string(data))
+	return nil
+}
+
+func (t *loggingTransport) redactHeaders(header http.Header) {
+	for _, names := range [][]string{redactedHeaders, t.redact} {
+		for _, name := range names {
+			if header.Get(name) != "" {
+				header.Set(name, "[REDACTED]")
+			}
+		}
+	}
+}
+
+const logReqMsg = `Grafana API Request Details:
+---[ REQUEST ]---------------------------------------
+%s
+-----------------------------------------------------`
+
+const logRespMsg = `Grafana API Response Details:
+---[ RESPONSE ]--------------------------------------
+%s
+-----------------------------------------------------`
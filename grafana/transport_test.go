@@ -0,0 +1,246 @@
+package grafana
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestContextTransport_cancel(t *testing.T) {
+	requested := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(requested)
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	client := &http.Client{
+		Transport: &contextTransport{ctx, http.DefaultTransport},
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := client.Get(server.URL)
+		errCh <- err
+	}()
+
+	<-requested
+	cancel()
+
+	if err := <-errCh; err == nil {
+		t.Fatalf("expected the in-flight request to be aborted")
+	}
+}
+
+func TestHeaderTransport(t *testing.T) {
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header
+	}))
+	defer server.Close()
+
+	transport := &headerTransport{
+		headers: map[string]string{
+			"CF-Access-Client-Id": "my-client",
+			"X-Custom":            "value",
+		},
+		transport: http.DefaultTransport,
+	}
+
+	req, err := http.NewRequest("GET", server.URL, nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	resp.Body.Close()
+
+	if v := got.Get("CF-Access-Client-Id"); v != "my-client" {
+		t.Errorf("expected CF-Access-Client-Id header to be my-client, got %q", v)
+	}
+	if v := got.Get("X-Custom"); v != "value" {
+		t.Errorf("expected X-Custom header to be value, got %q", v)
+	}
+	if len(req.Header) != 0 {
+		t.Errorf("expected the original request to be left untouched, got %v", req.Header)
+	}
+}
+
+func TestLoggingTransport_redactsCredentials(t *testing.T) {
+	var gotAuth, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		body, _ := ioutil.ReadAll(r.Body)
+		gotBody = string(body)
+		http.SetCookie(w, &http.Cookie{Name: "grafana_session", Value: "session-secret"})
+		w.Write([]byte(`{"message": "ok"}`))
+	}))
+	defer server.Close()
+
+	oldTFLog := os.Getenv("TF_LOG")
+	os.Setenv("TF_LOG", "DEBUG")
+	defer os.Setenv("TF_LOG", oldTFLog)
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	transport := &loggingTransport{
+		redact:    []string{"CF-Access-Client-Secret"},
+		transport: http.DefaultTransport,
+	}
+
+	req, err := http.NewRequest("POST", server.URL+"/api/dashboards/db", bytes.NewBufferString(`{"title": "test"}`))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	req.Header.Set("Authorization", "Bearer api-key-secret")
+	req.Header.Set("CF-Access-Client-Secret", "proxy-secret")
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if gotAuth != "Bearer api-key-secret" {
+		t.Errorf("expected credentials to be sent to the server, got %q", gotAuth)
+	}
+	if gotBody != `{"title": "test"}` {
+		t.Errorf("expected request body to be sent to the server, got %q", gotBody)
+	}
+	if string(body) != `{"message": "ok"}` {
+		t.Errorf("expected response body to be readable, got %q", body)
+	}
+	if resp.Header.Get("Set-Cookie") == "[REDACTED]" {
+		t.Errorf("expected the response headers to be left untouched")
+	}
+
+	logged := buf.String()
+	for _, secret := range []string{"api-key-secret", "proxy-secret", "session-secret"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("expected %q to be redacted from the log:\n%s", secret, logged)
+		}
+	}
+	for _, expected := range []string{"/api/dashboards/db", `{"title": "test"}`, `{"message": "ok"}`} {
+		if !strings.Contains(logged, expected) {
+			t.Errorf("expected %q to be logged:\n%s", expected, logged)
+		}
+	}
+}
+
+func TestRateLimitTransport_rate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: newRateLimitTransport(20, 0, http.DefaultTransport),
+	}
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				t.Errorf("err: %s", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	// Five requests at 20 per second need at least four 50ms gaps.
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Fatalf("expected requests to be spread over at least 200ms, took %s", elapsed)
+	}
+}
+
+func TestRateLimitTransport_maxParallel(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: newRateLimitTransport(0, 2, http.DefaultTransport),
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				t.Errorf("err: %s", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight > 2 {
+		t.Fatalf("expected at most 2 requests in flight, got %d", maxInFlight)
+	}
+}
+
+func TestUserAgentTransport(t *testing.T) {
+	var agents, ids []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		agents = append(agents, r.Header.Get("User-Agent"))
+		ids = append(ids, r.Header.Get("X-Request-Id"))
+	}))
+	defer server.Close()
+
+	for _, requestID := range []bool{false, true, true} {
+		client := &http.Client{
+			Transport: &userAgentTransport{"terraform-provider-grafana", requestID, http.DefaultTransport},
+		}
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		resp.Body.Close()
+	}
+
+	for _, agent := range agents {
+		if agent != "terraform-provider-grafana" {
+			t.Errorf("expected the provider's User-Agent, got %q", agent)
+		}
+	}
+	if ids[0] != "" {
+		t.Errorf("expected no request ID unless enabled, got %q", ids[0])
+	}
+	if ids[1] == "" || ids[2] == "" || ids[1] == ids[2] {
+		t.Errorf("expected unique request IDs, got %q and %q", ids[1], ids[2])
+	}
+}